@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"io/fs"
 	"log"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
+	// Embed the time zone database for billing calendars
+	_ "time/tzdata"
 
 	"connectrpc.com/connect"
 	"golang.org/x/net/http2"
@@ -14,10 +19,13 @@ import (
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/gen/proto/pricing/v1/pricingv1connect"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/handler/pricing"
+	pricingrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/pricing"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/config"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/di"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/infrastructure/server"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/infrastructure/static"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/infrastructure/worker"
 	"github.com/samber/do/v2"
 )
 
@@ -35,6 +43,11 @@ func main() {
 	container := di.NewContainer(cfg)
 	defer container.Shutdown()
 
+	// Record catalog changes in the background
+	if cfg.Catalog.HistoryInterval > 0 {
+		do.MustInvoke[*worker.CatalogRecorder](container).Start()
+	}
+
 	// Get the pricing handler from the container
 	pricingHandler := do.MustInvoke[*pricing.Handler](container)
 
@@ -51,7 +64,16 @@ func main() {
 
 	// Register API routes under /api/
 	apiPath := "/api" + path
-	mux.Handle(apiPath, http.StripPrefix("/api", corsMiddleware(handler, cfg)))
+	mux.Handle(apiPath, http.StripPrefix("/api", corsMiddleware(streamingMiddleware(handler, pricingv1connect.PricingServiceRecalculateSessionProcedure), cfg)))
+
+	// Expose the catalog cache counters, when debug endpoints are enabled
+	if cfg.Server.DebugEndpoints {
+		mux.Handle("GET /debug/catalog-cache", cacheStatsHandler(map[string]any{
+			"instances": do.MustInvoke[repository.PricingRepository](container),
+			"addons":    do.MustInvoke[repository.AddonCatalogRepository](container),
+			"zones":     do.MustInvoke[repository.ZoneRepository](container),
+		}))
+	}
 
 	// Serve static files for the SPA
 	webSubFS, err := fs.Sub(webFS, "web")
@@ -101,6 +123,42 @@ func corsMiddleware(h http.Handler, cfg *config.Config) http.Handler {
 	})
 }
 
+// cacheStatsHandler serves the counters of the cached catalog repositories as
+// JSON, by catalog. Repositories without a cache are left out.
+func cacheStatsHandler(repos map[string]any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := make(map[string]pricingrepo.CacheStats, len(repos))
+		for name, repo := range repos {
+			if reporter, ok := repo.(pricingrepo.CacheStatsReporter); ok {
+				stats[name] = reporter.Stats()
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats); err != nil {
+			log.Printf("Failed to write catalog cache stats: %v", err)
+		}
+	})
+}
+
+// streamingMiddleware lifts the server read and write timeouts for streaming
+// procedures, which last as long as the client keeps the stream open.
+func streamingMiddleware(h http.Handler, procedures ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(procedures, r.URL.Path) {
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(time.Time{}); err != nil {
+				log.Printf("Failed to lift read timeout of %s: %v", r.URL.Path, err)
+			}
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+				log.Printf("Failed to lift write timeout of %s: %v", r.URL.Path, err)
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
 // loggingInterceptor logs all RPC calls.
 type loggingInterceptor struct{}
 
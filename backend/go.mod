@@ -8,6 +8,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/samber/do/v2 v2.0.0
 	golang.org/x/net v0.34.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250127172529-29210b9bc287
 	google.golang.org/protobuf v1.36.4
 )
 
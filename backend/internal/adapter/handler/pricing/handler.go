@@ -1,23 +1,54 @@
 package pricing
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
 
 	"connectrpc.com/connect"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/gen/proto/pricing/v1"
 	"github.com/c18t-com/clever-pricing-calculator/backend/gen/proto/pricing/v1/pricingv1connect"
+	pricingrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/pricing"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/command"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/query"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
 )
 
 // Handler implements the PricingServiceHandler interface.
 type Handler struct {
-	listInstancesHandler  *query.ListInstancesHandler
-	getEstimationHandler  *query.GetEstimationHandler
-	calculateCostHandler  *command.CalculateCostHandler
-	saveEstimationHandler *command.SaveEstimationHandler
+	listInstancesHandler        *query.ListInstancesHandler
+	listAddonProvidersHandler   *query.ListAddonProvidersHandler
+	listZonesHandler            *query.ListZonesHandler
+	exportCatalogHandler        *query.ExportCatalogHandler
+	listCatalogSnapshotsHandler *query.ListCatalogSnapshotsHandler
+	diffCatalogHandler          *query.DiffCatalogHandler
+	simulateScalingHandler      *query.SimulateScalingHandler
+	getEstimationHandler        *query.GetEstimationHandler
+	calculateCostHandler        *command.CalculateCostHandler
+	saveEstimationHandler       *command.SaveEstimationHandler
+	recomputeEstimationHandler  *command.RecomputeEstimationHandler
+	simulateCostHandler         *command.SimulateCostHandler
+	projectCostHandler          *query.ProjectCostHandler
+	computeCostHeatmapHandler   *command.ComputeCostHeatmapHandler
+	evaluateBudgetHandler       *query.EvaluateBudgetHandler
+	listBudgetAlertsHandler     *query.ListBudgetAlertsHandler
+	setBudgetHandler            *command.SetBudgetHandler
+	getTaxProfileHandler        *query.GetTaxProfileHandler
+	setTaxProfileHandler        *command.SetTaxProfileHandler
+	getPricingAgreementHandler  *query.GetPricingAgreementHandler
+	setPricingAgreementHandler  *command.SetPricingAgreementHandler
+	suggestOptimizationsHandler *command.SuggestOptimizationsHandler
+	calculateCostBatchHandler   *command.CalculateCostBatchHandler
+	recalculationSessionHandler *command.RecalculationSessionHandler
 }
 
 // Ensure Handler implements the PricingServiceHandler interface.
@@ -26,15 +57,55 @@ var _ pricingv1connect.PricingServiceHandler = (*Handler)(nil)
 // NewHandler creates a new Handler with the given CQS handlers.
 func NewHandler(
 	listInstancesHandler *query.ListInstancesHandler,
+	listAddonProvidersHandler *query.ListAddonProvidersHandler,
+	listZonesHandler *query.ListZonesHandler,
+	exportCatalogHandler *query.ExportCatalogHandler,
+	listCatalogSnapshotsHandler *query.ListCatalogSnapshotsHandler,
+	diffCatalogHandler *query.DiffCatalogHandler,
+	simulateScalingHandler *query.SimulateScalingHandler,
 	getEstimationHandler *query.GetEstimationHandler,
 	calculateCostHandler *command.CalculateCostHandler,
 	saveEstimationHandler *command.SaveEstimationHandler,
+	recomputeEstimationHandler *command.RecomputeEstimationHandler,
+	simulateCostHandler *command.SimulateCostHandler,
+	projectCostHandler *query.ProjectCostHandler,
+	computeCostHeatmapHandler *command.ComputeCostHeatmapHandler,
+	evaluateBudgetHandler *query.EvaluateBudgetHandler,
+	listBudgetAlertsHandler *query.ListBudgetAlertsHandler,
+	setBudgetHandler *command.SetBudgetHandler,
+	getTaxProfileHandler *query.GetTaxProfileHandler,
+	setTaxProfileHandler *command.SetTaxProfileHandler,
+	getPricingAgreementHandler *query.GetPricingAgreementHandler,
+	setPricingAgreementHandler *command.SetPricingAgreementHandler,
+	suggestOptimizationsHandler *command.SuggestOptimizationsHandler,
+	calculateCostBatchHandler *command.CalculateCostBatchHandler,
+	recalculationSessionHandler *command.RecalculationSessionHandler,
 ) *Handler {
 	return &Handler{
-		listInstancesHandler:  listInstancesHandler,
-		getEstimationHandler:  getEstimationHandler,
-		calculateCostHandler:  calculateCostHandler,
-		saveEstimationHandler: saveEstimationHandler,
+		listInstancesHandler:        listInstancesHandler,
+		listAddonProvidersHandler:   listAddonProvidersHandler,
+		listZonesHandler:            listZonesHandler,
+		exportCatalogHandler:        exportCatalogHandler,
+		listCatalogSnapshotsHandler: listCatalogSnapshotsHandler,
+		diffCatalogHandler:          diffCatalogHandler,
+		simulateScalingHandler:      simulateScalingHandler,
+		getEstimationHandler:        getEstimationHandler,
+		calculateCostHandler:        calculateCostHandler,
+		saveEstimationHandler:       saveEstimationHandler,
+		recomputeEstimationHandler:  recomputeEstimationHandler,
+		simulateCostHandler:         simulateCostHandler,
+		projectCostHandler:          projectCostHandler,
+		computeCostHeatmapHandler:   computeCostHeatmapHandler,
+		evaluateBudgetHandler:       evaluateBudgetHandler,
+		listBudgetAlertsHandler:     listBudgetAlertsHandler,
+		setBudgetHandler:            setBudgetHandler,
+		getTaxProfileHandler:        getTaxProfileHandler,
+		setTaxProfileHandler:        setTaxProfileHandler,
+		getPricingAgreementHandler:  getPricingAgreementHandler,
+		setPricingAgreementHandler:  setPricingAgreementHandler,
+		suggestOptimizationsHandler: suggestOptimizationsHandler,
+		calculateCostBatchHandler:   calculateCostBatchHandler,
+		recalculationSessionHandler: recalculationSessionHandler,
 	}
 }
 
@@ -60,6 +131,153 @@ func (h *Handler) ListInstances(
 	}), nil
 }
 
+// ListAddonProviders handles the ListAddonProviders RPC.
+func (h *Handler) ListAddonProviders(
+	ctx context.Context,
+	req *connect.Request[pricingv1.ListAddonProvidersRequest],
+) (*connect.Response[pricingv1.ListAddonProvidersResponse], error) {
+	result, err := h.listAddonProvidersHandler.Handle(ctx, &query.ListAddonProvidersQuery{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoProviders := make([]*pricingv1.AddonProvider, 0, len(result.Providers))
+	for _, p := range result.Providers {
+		protoProviders = append(protoProviders, addonProviderToProto(p))
+	}
+
+	return connect.NewResponse(&pricingv1.ListAddonProvidersResponse{
+		Providers: protoProviders,
+	}), nil
+}
+
+// ListZones handles the ListZones RPC.
+func (h *Handler) ListZones(
+	ctx context.Context,
+	req *connect.Request[pricingv1.ListZonesRequest],
+) (*connect.Response[pricingv1.ListZonesResponse], error) {
+	result, err := h.listZonesHandler.Handle(ctx, &query.ListZonesQuery{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoZones := make([]*pricingv1.Zone, 0, len(result.Zones))
+	for _, z := range result.Zones {
+		protoZones = append(protoZones, zoneToProto(z))
+	}
+
+	return connect.NewResponse(&pricingv1.ListZonesResponse{
+		Zones: protoZones,
+	}), nil
+}
+
+// ExportCatalogSnapshot handles the ExportCatalogSnapshot RPC.
+func (h *Handler) ExportCatalogSnapshot(
+	ctx context.Context,
+	req *connect.Request[pricingv1.ExportCatalogSnapshotRequest],
+) (*connect.Response[pricingv1.ExportCatalogSnapshotResponse], error) {
+	result, err := h.exportCatalogHandler.Handle(ctx, &query.ExportCatalogQuery{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var buf bytes.Buffer
+	if err := pricingrepo.EncodeSnapshot(&buf, result.Snapshot); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.ExportCatalogSnapshotResponse{
+		Snapshot:    buf.Bytes(),
+		GeneratedAt: timestamppb.New(result.Snapshot.GeneratedAt),
+	}), nil
+}
+
+// ListCatalogSnapshots handles the ListCatalogSnapshots RPC.
+func (h *Handler) ListCatalogSnapshots(
+	ctx context.Context,
+	req *connect.Request[pricingv1.ListCatalogSnapshotsRequest],
+) (*connect.Response[pricingv1.ListCatalogSnapshotsResponse], error) {
+	result, err := h.listCatalogSnapshotsHandler.Handle(ctx, &query.ListCatalogSnapshotsQuery{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoSnapshots := make([]*pricingv1.CatalogSnapshotInfo, 0, len(result.Snapshots))
+	for _, s := range result.Snapshots {
+		protoSnapshots = append(protoSnapshots, &pricingv1.CatalogSnapshotInfo{
+			Id:          s.ID,
+			GeneratedAt: timestamppb.New(s.GeneratedAt),
+			Checksum:    s.Checksum(),
+		})
+	}
+
+	return connect.NewResponse(&pricingv1.ListCatalogSnapshotsResponse{
+		Snapshots: protoSnapshots,
+	}), nil
+}
+
+// DiffCatalog handles the DiffCatalog RPC.
+func (h *Handler) DiffCatalog(
+	ctx context.Context,
+	req *connect.Request[pricingv1.DiffCatalogRequest],
+) (*connect.Response[pricingv1.DiffCatalogResponse], error) {
+	result, err := h.diffCatalogHandler.Handle(ctx, &query.DiffCatalogQuery{
+		From: protoToCatalogRef(req.Msg.GetFrom()),
+		To:   protoToCatalogRef(req.Msg.GetTo()),
+	})
+	if err != nil {
+		if errors.Is(err, query.ErrCatalogSnapshotNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.DiffCatalogResponse{
+		Diff: catalogDiffToProto(result.Diff),
+	}), nil
+}
+
+// SimulateScaling handles the SimulateScaling RPC.
+func (h *Handler) SimulateScaling(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SimulateScalingRequest],
+) (*connect.Response[pricingv1.SimulateScalingResponse], error) {
+	var profile *entity.ScalingProfile
+	if p := req.Msg.GetProfile(); p != nil {
+		profile = protoToScalingProfile(p)
+	}
+
+	result, err := h.simulateScalingHandler.Handle(ctx, &query.SimulateScalingQuery{
+		ZoneID:       req.Msg.GetZoneId(),
+		InstanceType: req.Msg.GetInstanceType(),
+		Profile:      profile,
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, repository.ErrNotInCatalog) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoStates := make([]*pricingv1.ScalingState, 0, len(result.States))
+	for _, st := range result.States {
+		protoStates = append(protoStates, &pricingv1.ScalingState{
+			LoadLevel:   st.LoadLevel,
+			FlavorName:  st.FlavorName,
+			FlavorIndex: int32(st.FlavorIndex),
+			Instances:   st.Instances,
+			HourlyCost:  moneyToProto(st.HourlyCost),
+		})
+	}
+
+	return connect.NewResponse(&pricingv1.SimulateScalingResponse{
+		States: protoStates,
+	}), nil
+}
+
 // GetEstimation handles the GetEstimation RPC.
 func (h *Handler) GetEstimation(
 	ctx context.Context,
@@ -69,6 +287,9 @@ func (h *Handler) GetEstimation(
 		EstimationID: req.Msg.GetEstimationId(),
 	})
 	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
 		if err == query.ErrEstimationNotFound {
 			return nil, connect.NewError(connect.CodeNotFound, err)
 		}
@@ -80,38 +301,152 @@ func (h *Handler) GetEstimation(
 	}), nil
 }
 
-// CalculateCost handles the CalculateCost RPC.
-func (h *Handler) CalculateCost(
+// ProjectCost handles the ProjectCost RPC.
+func (h *Handler) ProjectCost(
 	ctx context.Context,
-	req *connect.Request[pricingv1.CalculateCostRequest],
-) (*connect.Response[pricingv1.CalculateCostResponse], error) {
-	runtimeSpecs := make([]*command.RuntimeSpec, 0, len(req.Msg.GetRuntimeSpecs()))
-	for _, spec := range req.Msg.GetRuntimeSpecs() {
-		runtimeSpecs = append(runtimeSpecs, &command.RuntimeSpec{
-			InstanceType: spec.GetInstanceType(),
-			FlavorName:   spec.GetFlavorName(),
-			MinInstances: spec.GetMinInstances(),
-			MaxInstances: spec.GetMaxInstances(),
-		})
+	req *connect.Request[pricingv1.ProjectCostRequest],
+) (*connect.Response[pricingv1.ProjectCostResponse], error) {
+	var estimation *entity.CostEstimation
+	if req.Msg.GetEstimation() != nil {
+		var err error
+		estimation, err = protoToEstimation(req.Msg.GetEstimation())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 	}
 
-	addonSpecs := make([]*command.AddonSpec, 0, len(req.Msg.GetAddonSpecs()))
-	for _, spec := range req.Msg.GetAddonSpecs() {
-		addonSpecs = append(addonSpecs, &command.AddonSpec{
-			ProviderID: spec.GetProviderId(),
-			PlanID:     spec.GetPlanId(),
-		})
+	projection, err := h.projectCostHandler.Handle(ctx, &query.ProjectCostQuery{
+		EstimationID:        req.Msg.GetEstimationId(),
+		Estimation:          estimation,
+		Months:              int(req.Msg.GetMonths()),
+		StartYear:           int(req.Msg.GetStartYear()),
+		StartMonth:          time.Month(req.Msg.GetStartMonth()),
+		MonthlyGrowthRate:   req.Msg.GetMonthlyGrowthRate(),
+		SeasonalMultipliers: req.Msg.GetSeasonalMultipliers(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, query.ErrEstimationNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		if errors.Is(err, entity.ErrMoneyOverflow) {
+			return nil, connect.NewError(connect.CodeOutOfRange, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	estimation, err := h.calculateCostHandler.Handle(ctx, &command.CalculateCostCommand{
-		ProjectID:    req.Msg.GetProjectId(),
-		RuntimeSpecs: runtimeSpecs,
-		AddonSpecs:   addonSpecs,
+	return connect.NewResponse(&pricingv1.ProjectCostResponse{
+		Projection: costProjectionToProto(projection),
+	}), nil
+}
+
+// EvaluateBudget handles the EvaluateBudget RPC.
+func (h *Handler) EvaluateBudget(
+	ctx context.Context,
+	req *connect.Request[pricingv1.EvaluateBudgetRequest],
+) (*connect.Response[pricingv1.EvaluateBudgetResponse], error) {
+	evaluation, err := h.evaluateBudgetHandler.Handle(ctx, &query.EvaluateBudgetQuery{
+		OrganizationID: req.Msg.GetOrganizationId(),
+		ProjectID:      req.Msg.GetProjectId(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, query.ErrBudgetNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.EvaluateBudgetResponse{
+		Evaluation: budgetEvaluationToProto(evaluation),
+	}), nil
+}
+
+// ListBudgetAlerts handles the ListBudgetAlerts RPC.
+func (h *Handler) ListBudgetAlerts(
+	ctx context.Context,
+	req *connect.Request[pricingv1.ListBudgetAlertsRequest],
+) (*connect.Response[pricingv1.ListBudgetAlertsResponse], error) {
+	result, err := h.listBudgetAlertsHandler.Handle(ctx, &query.ListBudgetAlertsQuery{
+		OrganizationID: req.Msg.GetOrganizationId(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.ListBudgetAlertsResponse{
+		Alerts: budgetAlertsToProto(result.Alerts),
+	}), nil
+}
+
+// GetTaxProfile handles the GetTaxProfile RPC.
+func (h *Handler) GetTaxProfile(
+	ctx context.Context,
+	req *connect.Request[pricingv1.GetTaxProfileRequest],
+) (*connect.Response[pricingv1.GetTaxProfileResponse], error) {
+	profile, err := h.getTaxProfileHandler.Handle(ctx, &query.GetTaxProfileQuery{
+		OrganizationID: req.Msg.GetOrganizationId(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, query.ErrTaxProfileNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.GetTaxProfileResponse{
+		Profile: taxProfileToProto(profile),
+	}), nil
+}
+
+// GetPricingAgreement handles the GetPricingAgreement RPC.
+func (h *Handler) GetPricingAgreement(
+	ctx context.Context,
+	req *connect.Request[pricingv1.GetPricingAgreementRequest],
+) (*connect.Response[pricingv1.GetPricingAgreementResponse], error) {
+	agreement, err := h.getPricingAgreementHandler.Handle(ctx, &query.GetPricingAgreementQuery{
+		OrganizationID: req.Msg.GetOrganizationId(),
 	})
 	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, query.ErrPricingAgreementNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	return connect.NewResponse(&pricingv1.GetPricingAgreementResponse{
+		Agreement: pricingAgreementToProto(agreement),
+	}), nil
+}
+
+// CalculateCost handles the CalculateCost RPC.
+func (h *Handler) CalculateCost(
+	ctx context.Context,
+	req *connect.Request[pricingv1.CalculateCostRequest],
+) (*connect.Response[pricingv1.CalculateCostResponse], error) {
+	cmd, err := protoToCalculateCostCommand(req.Msg, "")
+	if err != nil {
+		return nil, err
+	}
+
+	estimation, err := h.calculateCostHandler.Handle(ctx, cmd)
+	if err != nil {
+		return nil, calculateCostError(err, "")
+	}
+
 	return connect.NewResponse(&pricingv1.CalculateCostResponse{
 		Estimation: estimationToProto(estimation),
 	}), nil
@@ -122,102 +457,1681 @@ func (h *Handler) SaveEstimation(
 	ctx context.Context,
 	req *connect.Request[pricingv1.SaveEstimationRequest],
 ) (*connect.Response[pricingv1.SaveEstimationResponse], error) {
-	estimation := protoToEstimation(req.Msg.GetEstimation())
+	estimation, err := protoToEstimation(req.Msg.GetEstimation())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
-	id, err := h.saveEstimationHandler.Handle(ctx, &command.SaveEstimationCommand{
+	result, err := h.saveEstimationHandler.Handle(ctx, &command.SaveEstimationCommand{
 		Estimation: estimation,
 	})
 	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
 	return connect.NewResponse(&pricingv1.SaveEstimationResponse{
-		EstimationId: id,
+		EstimationId: result.EstimationID,
+		Alerts:       budgetAlertsToProto(result.Alerts),
 	}), nil
 }
 
-// Conversion helpers
+// RecomputeEstimation handles the RecomputeEstimation RPC.
+func (h *Handler) RecomputeEstimation(
+	ctx context.Context,
+	req *connect.Request[pricingv1.RecomputeEstimationRequest],
+) (*connect.Response[pricingv1.RecomputeEstimationResponse], error) {
+	result, err := h.recomputeEstimationHandler.Handle(ctx, &command.RecomputeEstimationCommand{
+		EstimationID: req.Msg.GetEstimationId(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		switch {
+		case errors.Is(err, query.ErrEstimationNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, command.ErrEstimationNotPriceLocked):
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
-func instanceToProto(inst *entity.Instance) *pricingv1.Instance {
-	flavors := make([]*pricingv1.Flavor, 0, len(inst.Flavors))
-	for _, f := range inst.Flavors {
-		flavors = append(flavors, &pricingv1.Flavor{
-			Name:         f.Name,
-			Mem:          f.Mem,
-			Cpus:         f.CPUs,
-			PricePerHour: f.PricePerHour,
-			Available:    f.Available,
-		})
+	return connect.NewResponse(&pricingv1.RecomputeEstimationResponse{
+		Original: estimationToProto(result.Original),
+		Current:  estimationToProto(result.Current),
+		Drift:    estimationDriftToProto(result.Drift),
+	}), nil
+}
+
+// SimulateCost handles the SimulateCost RPC.
+func (h *Handler) SimulateCost(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SimulateCostRequest],
+) (*connect.Response[pricingv1.SimulateCostResponse], error) {
+	runtimeSpecs, err := protoToRuntimeSpecs(req.Msg.GetRuntimeSpecs())
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	return &pricingv1.Instance{
-		Type:    inst.Type,
-		Name:    inst.Name,
-		Version: inst.Version,
-		Flavors: flavors,
+	calendar, err := protoToBillingCalendar(req.Msg.GetBillingCalendar())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	loadModels := make([]*entity.WeeklyLoadModel, 0, len(req.Msg.GetLoadModels()))
+	for _, m := range req.Msg.GetLoadModels() {
+		loadModel, err := protoToWeeklyLoadModel(m)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		loadModels = append(loadModels, loadModel)
+	}
+
+	simulation, err := h.simulateCostHandler.Handle(ctx, &command.SimulateCostCommand{
+		ProjectID:        req.Msg.GetProjectId(),
+		ZoneID:           req.Msg.GetZoneId(),
+		RuntimeSpecs:     runtimeSpecs,
+		AddonSpecs:       protoToAddonSpecs(req.Msg.GetAddonSpecs()),
+		BillingCalendar:  calendar,
+		LoadModels:       loadModels,
+		Iterations:       int(req.Msg.GetIterations()),
+		Seed:             req.Msg.GetSeed(),
+		HistogramBuckets: int(req.Msg.GetHistogramBuckets()),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		switch {
+		case errors.Is(err, command.ErrInvalidUsageEstimate):
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		case errors.Is(err, repository.ErrNotInCatalog):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+
+	return connect.NewResponse(&pricingv1.SimulateCostResponse{
+		Simulation: costSimulationToProto(simulation),
+	}), nil
 }
 
-func estimationToProto(est *entity.CostEstimation) *pricingv1.CostEstimation {
-	runtimeCosts := make([]*pricingv1.RuntimeCost, 0, len(est.RuntimeCosts))
-	for _, rc := range est.RuntimeCosts {
-		runtimeCosts = append(runtimeCosts, &pricingv1.RuntimeCost{
-			RuntimeId: rc.RuntimeID,
-			Name:      rc.Name,
-			MinCost:   rc.MinCost,
-			MaxCost:   rc.MaxCost,
-		})
+// GetCostHeatmap handles the GetCostHeatmap RPC.
+func (h *Handler) GetCostHeatmap(
+	ctx context.Context,
+	req *connect.Request[pricingv1.GetCostHeatmapRequest],
+) (*connect.Response[pricingv1.GetCostHeatmapResponse], error) {
+	runtimeSpecs, err := protoToRuntimeSpecs(req.Msg.GetRuntimeSpecs())
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	addonCosts := make([]*pricingv1.AddonCost, 0, len(est.AddonCosts))
-	for _, ac := range est.AddonCosts {
-		addonCosts = append(addonCosts, &pricingv1.AddonCost{
-			AddonId: ac.AddonID,
-			Name:    ac.Name,
-			Cost:    ac.Cost,
-		})
+	heatmap, err := h.computeCostHeatmapHandler.Handle(ctx, &command.ComputeCostHeatmapCommand{
+		ProjectID:    req.Msg.GetProjectId(),
+		ZoneID:       req.Msg.GetZoneId(),
+		RuntimeSpecs: runtimeSpecs,
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, repository.ErrNotInCatalog) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return &pricingv1.CostEstimation{
-		Id:             est.ID,
-		ProjectId:      est.ProjectID,
-		MinMonthlyCost: est.MinMonthlyCost,
-		MaxMonthlyCost: est.MaxMonthlyCost,
-		RuntimeCosts:   runtimeCosts,
-		AddonCosts:     addonCosts,
+	return connect.NewResponse(&pricingv1.GetCostHeatmapResponse{
+		Heatmap: costHeatmapToProto(heatmap),
+	}), nil
+}
+
+// SetBudget handles the SetBudget RPC.
+func (h *Handler) SetBudget(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SetBudgetRequest],
+) (*connect.Response[pricingv1.SetBudgetResponse], error) {
+	monthlyLimit, err := protoToMoney(req.Msg.GetMonthlyLimit())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	budget, err := h.setBudgetHandler.Handle(ctx, &command.SetBudgetCommand{
+		OrganizationID:    req.Msg.GetOrganizationId(),
+		ProjectID:         req.Msg.GetProjectId(),
+		MonthlyLimit:      monthlyLimit,
+		WarningThreshold:  req.Msg.GetWarningThreshold(),
+		CriticalThreshold: req.Msg.GetCriticalThreshold(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, command.ErrInvalidBudget) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+
+	return connect.NewResponse(&pricingv1.SetBudgetResponse{
+		Budget: budgetToProto(budget),
+	}), nil
 }
 
-func protoToEstimation(proto *pricingv1.CostEstimation) *entity.CostEstimation {
-	if proto == nil {
-		return nil
+// SetTaxProfile handles the SetTaxProfile RPC.
+func (h *Handler) SetTaxProfile(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SetTaxProfileRequest],
+) (*connect.Response[pricingv1.SetTaxProfileResponse], error) {
+	p := req.Msg.GetProfile()
+	if p == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("tax profile is required"))
 	}
 
-	estimation := &entity.CostEstimation{
-		ID:             proto.GetId(),
-		ProjectID:      proto.GetProjectId(),
-		MinMonthlyCost: proto.GetMinMonthlyCost(),
-		MaxMonthlyCost: proto.GetMaxMonthlyCost(),
-		RuntimeCosts:   make([]*entity.RuntimeCost, 0, len(proto.GetRuntimeCosts())),
-		AddonCosts:     make([]*entity.AddonCost, 0, len(proto.GetAddonCosts())),
+	profile, err := h.setTaxProfileHandler.Handle(ctx, &command.SetTaxProfileCommand{
+		OrganizationID:  p.GetOrganizationId(),
+		Country:         p.GetCountry(),
+		VATNumber:       p.GetVatNumber(),
+		Exempt:          p.GetExempt(),
+		ExemptionReason: p.GetExemptionReason(),
+	})
+	if err != nil {
+		if verr := validationError(err, "profile"); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, command.ErrInvalidTaxProfile) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	for _, rc := range proto.GetRuntimeCosts() {
-		estimation.RuntimeCosts = append(estimation.RuntimeCosts, &entity.RuntimeCost{
-			RuntimeID: rc.GetRuntimeId(),
-			Name:      rc.GetName(),
-			MinCost:   rc.GetMinCost(),
-			MaxCost:   rc.GetMaxCost(),
-		})
+	return connect.NewResponse(&pricingv1.SetTaxProfileResponse{
+		Profile: taxProfileToProto(profile),
+	}), nil
+}
+
+// SetPricingAgreement handles the SetPricingAgreement RPC.
+func (h *Handler) SetPricingAgreement(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SetPricingAgreementRequest],
+) (*connect.Response[pricingv1.SetPricingAgreementResponse], error) {
+	a := req.Msg.GetAgreement()
+	if a == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("pricing agreement is required"))
 	}
 
-	for _, ac := range proto.GetAddonCosts() {
-		estimation.AddonCosts = append(estimation.AddonCosts, &entity.AddonCost{
-			AddonID: ac.GetAddonId(),
-			Name:    ac.GetName(),
-			Cost:    ac.GetCost(),
-		})
+	creditBalance, err := protoToMoney(a.GetCreditBalance())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	monthlyCommitment, err := protoToMoney(a.GetMonthlyCommitment())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	overrides, err := protoToFlavorPriceOverrides(a.GetFlavorPriceOverrides())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	agreement, err := h.setPricingAgreementHandler.Handle(ctx, &command.SetPricingAgreementCommand{
+		OrganizationID:       a.GetOrganizationId(),
+		CreditBalance:        creditBalance,
+		MonthlyCommitment:    monthlyCommitment,
+		DiscountPercent:      a.GetDiscountPercent(),
+		FlavorPriceOverrides: overrides,
+	})
+	if err != nil {
+		if verr := validationError(err, "agreement"); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, command.ErrInvalidPricingAgreement) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.SetPricingAgreementResponse{
+		Agreement: pricingAgreementToProto(agreement),
+	}), nil
+}
 
-	return estimation
+// SuggestOptimizations handles the SuggestOptimizations RPC.
+func (h *Handler) SuggestOptimizations(
+	ctx context.Context,
+	req *connect.Request[pricingv1.SuggestOptimizationsRequest],
+) (*connect.Response[pricingv1.SuggestOptimizationsResponse], error) {
+	runtimeSpecs, err := protoToRuntimeSpecs(req.Msg.GetRuntimeSpecs())
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	report, err := h.suggestOptimizationsHandler.Handle(ctx, &command.SuggestOptimizationsCommand{
+		ProjectID:      req.Msg.GetProjectId(),
+		OrganizationID: req.Msg.GetOrganizationId(),
+		ZoneID:         req.Msg.GetZoneId(),
+		RuntimeSpecs:   runtimeSpecs,
+		Staging:        req.Msg.GetStaging(),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		if errors.Is(err, repository.ErrNotInCatalog) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.SuggestOptimizationsResponse{
+		Report: optimizationReportToProto(report),
+	}), nil
+}
+
+// CalculateCostBatch handles the CalculateCostBatch RPC.
+func (h *Handler) CalculateCostBatch(
+	ctx context.Context,
+	req *connect.Request[pricingv1.CalculateCostBatchRequest],
+) (*connect.Response[pricingv1.CalculateCostBatchResponse], error) {
+	items := make([]*command.CalculateCostBatchItem, 0, len(req.Msg.GetItems()))
+	for _, item := range req.Msg.GetItems() {
+		cmd, err := protoToCalculateCostCommand(item, "")
+		items = append(items, &command.CalculateCostBatchItem{Command: cmd, Err: err})
+	}
+
+	batch, err := h.calculateCostBatchHandler.Handle(ctx, &command.CalculateCostBatchCommand{
+		OrganizationID: req.Msg.GetOrganizationId(),
+		Items:          items,
+		MaxWorkers:     int(req.Msg.GetMaxConcurrency()),
+	})
+	if err != nil {
+		if verr := validationError(err, ""); verr != nil {
+			return nil, verr
+		}
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil, connect.NewError(connect.CodeCanceled, err)
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, connect.NewError(connect.CodeDeadlineExceeded, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pricingv1.CalculateCostBatchResponse{
+		Items: batchItemsToProto(batch.Items),
+		Total: organizationCostTotalToProto(batch.Total),
+	}), nil
+}
+
+// RecalculateSession handles the RecalculateSession RPC. A rejected message
+// is answered with its error and does not end the session.
+func (h *Handler) RecalculateSession(
+	ctx context.Context,
+	stream *connect.BidiStream[pricingv1.RecalculateSessionRequest, pricingv1.RecalculateSessionResponse],
+) error {
+	var session *command.RecalculationSession
+	for {
+		req, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var estimation *entity.CostEstimation
+		session, estimation, err = h.recalculate(ctx, session, req)
+		resp := &pricingv1.RecalculateSessionResponse{}
+		if session != nil {
+			resp.Revision = int32(session.Revision())
+		}
+		if err != nil {
+			resp.Result = &pricingv1.RecalculateSessionResponse_Error{Error: operationErrorToProto(calculateCostError(err, ""))}
+		} else {
+			resp.Result = &pricingv1.RecalculateSessionResponse_Estimation{Estimation: estimationToProto(estimation)}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// recalculate applies a message of a recalculation session and returns the
+// session, a new one for start messages, with its estimation. Validation
+// errors are converted to Connect errors with paths in the message.
+func (h *Handler) recalculate(
+	ctx context.Context,
+	session *command.RecalculationSession,
+	req *pricingv1.RecalculateSessionRequest,
+) (*command.RecalculationSession, *entity.CostEstimation, error) {
+	if start := req.GetStart(); start != nil {
+		cmd, err := protoToCalculateCostCommand(start, "start")
+		if err != nil {
+			return session, nil, err
+		}
+		started, err := h.recalculationSessionHandler.Handle(ctx, cmd)
+		if err != nil {
+			return session, nil, calculateCostError(err, "start")
+		}
+		estimation, err := started.Estimation(ctx)
+		return started, estimation, err
+	}
+
+	if session == nil {
+		return nil, nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("the session must begin with a start message"))
+	}
+	edit, field, err := protoToRecalculationEdit(req)
+	if err != nil {
+		if verr := validationError(err, field); verr != nil {
+			return session, nil, verr
+		}
+		return session, nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	estimation, err := session.Apply(ctx, edit)
+	if err != nil {
+		return session, nil, calculateCostError(err, field)
+	}
+	return session, estimation, nil
+}
+
+// Conversion helpers
+
+func instanceToProto(inst *entity.Instance) *pricingv1.Instance {
+	flavors := make([]*pricingv1.Flavor, 0, len(inst.Flavors))
+	for _, f := range inst.Flavors {
+		flavors = append(flavors, &pricingv1.Flavor{
+			Name:         f.Name,
+			Mem:          f.Mem,
+			Cpus:         f.CPUs,
+			PricePerHour: moneyToProto(f.PricePerHour),
+			Available:    f.Available,
+		})
+	}
+
+	return &pricingv1.Instance{
+		Type:         inst.Type,
+		Name:         inst.Name,
+		Version:      inst.Version,
+		MaxInstances: inst.MaxInstances,
+		Flavors:      flavors,
+	}
+}
+
+func addonProviderToProto(p *entity.AddonProvider) *pricingv1.AddonProvider {
+	plans := make([]*pricingv1.AddonPlan, 0, len(p.Plans))
+	for _, pl := range p.Plans {
+		plans = append(plans, &pricingv1.AddonPlan{
+			Id:       pl.ID,
+			Name:     pl.Name,
+			Slug:     pl.Slug,
+			Price:    moneyToProto(pl.Price),
+			PriceId:  pl.PriceID,
+			Features: addonFeaturesToProto(pl.Features),
+			Zones:    pl.Zones,
+		})
+	}
+
+	return &pricingv1.AddonProvider{
+		Id:        p.ID,
+		Name:      p.Name,
+		ShortDesc: p.ShortDesc,
+		LogoUrl:   p.LogoURL,
+		Status:    p.Status,
+		Regions:   p.Regions,
+		Plans:     plans,
+		Features:  addonFeaturesToProto(p.Features),
+	}
+}
+
+func addonFeaturesToProto(features []*entity.AddonFeature) []*pricingv1.AddonFeature {
+	result := make([]*pricingv1.AddonFeature, 0, len(features))
+	for _, f := range features {
+		result = append(result, &pricingv1.AddonFeature{
+			Name:     f.Name,
+			Type:     f.Type,
+			Value:    f.Value,
+			NameCode: f.NameCode,
+		})
+	}
+	return result
+}
+
+func zoneToProto(z *entity.Zone) *pricingv1.Zone {
+	return &pricingv1.Zone{
+		Name:        z.Name,
+		DisplayName: z.DisplayName,
+		Country:     z.Country,
+		CountryCode: z.CountryCode,
+		City:        z.City,
+		Lat:         z.Lat,
+		Lon:         z.Lon,
+		Tags:        z.Tags,
+	}
+}
+
+func protoToCatalogRef(ref *pricingv1.CatalogRef) query.CatalogRef {
+	switch r := ref.GetRef().(type) {
+	case *pricingv1.CatalogRef_SnapshotId:
+		return query.CatalogRef{SnapshotID: r.SnapshotId}
+	case *pricingv1.CatalogRef_At:
+		return query.CatalogRef{At: r.At.AsTime()}
+	default:
+		return query.CatalogRef{}
+	}
+}
+
+func catalogDiffToProto(d *entity.CatalogDiff) *pricingv1.CatalogDiff {
+	priceChanges := make([]*pricingv1.FlavorPriceChange, 0, len(d.PriceChanges))
+	for _, c := range d.PriceChanges {
+		priceChanges = append(priceChanges, &pricingv1.FlavorPriceChange{
+			Flavor:          flavorRefToProto(&c.FlavorRef),
+			OldPricePerHour: moneyToProto(c.OldPricePerHour),
+			NewPricePerHour: moneyToProto(c.NewPricePerHour),
+			Delta:           moneyToProto(c.Delta()),
+			DeltaPercent:    c.DeltaPercent(),
+		})
+	}
+
+	return &pricingv1.CatalogDiff{
+		FromSnapshotId:   d.FromID,
+		ToSnapshotId:     d.ToID,
+		FromGeneratedAt:  timestamppb.New(d.FromGeneratedAt),
+		ToGeneratedAt:    timestamppb.New(d.ToGeneratedAt),
+		AddedInstances:   instanceRefsToProto(d.AddedInstances),
+		RemovedInstances: instanceRefsToProto(d.RemovedInstances),
+		AddedFlavors:     flavorRefsToProto(d.AddedFlavors),
+		RemovedFlavors:   flavorRefsToProto(d.RemovedFlavors),
+		PriceChanges:     priceChanges,
+	}
+}
+
+func instanceRefsToProto(refs []*entity.InstanceRef) []*pricingv1.InstanceRef {
+	result := make([]*pricingv1.InstanceRef, 0, len(refs))
+	for _, r := range refs {
+		result = append(result, &pricingv1.InstanceRef{
+			ZoneId:       r.ZoneID,
+			InstanceType: r.InstanceType,
+		})
+	}
+	return result
+}
+
+func flavorRefsToProto(refs []*entity.FlavorRef) []*pricingv1.FlavorRef {
+	result := make([]*pricingv1.FlavorRef, 0, len(refs))
+	for _, r := range refs {
+		result = append(result, flavorRefToProto(r))
+	}
+	return result
+}
+
+func flavorRefToProto(r *entity.FlavorRef) *pricingv1.FlavorRef {
+	return &pricingv1.FlavorRef{
+		ZoneId:       r.ZoneID,
+		InstanceType: r.InstanceType,
+		FlavorName:   r.FlavorName,
+	}
+}
+
+func estimationToProto(est *entity.CostEstimation) *pricingv1.CostEstimation {
+	runtimeCosts := make([]*pricingv1.RuntimeCost, 0, len(est.RuntimeCosts))
+	for _, rc := range est.RuntimeCosts {
+		runtimeCosts = append(runtimeCosts, &pricingv1.RuntimeCost{
+			RuntimeId:             rc.RuntimeID,
+			Name:                  rc.Name,
+			MinCost:               moneyToProto(rc.MinCost),
+			MaxCost:               moneyToProto(rc.MaxCost),
+			UnitPrice:             moneyToProto(rc.UnitPrice),
+			Spec:                  runtimeSpecToProto(rc.Spec),
+			BaseCost:              moneyToProto(rc.BaseCost),
+			ScalingCost:           moneyToProto(rc.ScalingCost),
+			EstimatedCost:         moneyToProto(rc.EstimatedCost),
+			ScalingHours:          rc.ScalingHours,
+			AverageLoadLevel:      rc.AverageLoadLevel,
+			ScalingHoursByProfile: rc.ScalingHoursByProfile,
+			Tax:                   costRangeTaxToProto(rc.Tax),
+			ListCost:              moneyToProto(rc.ListCost),
+		})
+	}
+
+	addonCosts := make([]*pricingv1.AddonCost, 0, len(est.AddonCosts))
+	for _, ac := range est.AddonCosts {
+		addonCosts = append(addonCosts, &pricingv1.AddonCost{
+			AddonId:    ac.AddonID,
+			Name:       ac.Name,
+			Cost:       moneyToProto(ac.Cost),
+			UnitPrice:  moneyToProto(ac.UnitPrice),
+			Spec:       addonSpecToProto(ac.Spec),
+			UsageCost:  moneyToProto(ac.UsageCost),
+			UsageCosts: usageMetricCostsToProto(ac.UsageCosts),
+			Tax:        taxedAmountPtrToProto(ac.Tax),
+			ListCost:   moneyToProto(ac.ListCost),
+		})
+	}
+
+	var pricedAt *timestamppb.Timestamp
+	if !est.PricedAt.IsZero() {
+		pricedAt = timestamppb.New(est.PricedAt)
+	}
+
+	return &pricingv1.CostEstimation{
+		Id:                   est.ID,
+		ProjectId:            est.ProjectID,
+		OrganizationId:       est.OrganizationID,
+		MinMonthlyCost:       moneyToProto(est.MinMonthlyCost),
+		MaxMonthlyCost:       moneyToProto(est.MaxMonthlyCost),
+		EstimatedMonthlyCost: moneyToProto(est.EstimatedMonthlyCost),
+		RuntimeCosts:         runtimeCosts,
+		AddonCosts:           addonCosts,
+		CatalogVersion:       est.CatalogVersion,
+		PricedAt:             pricedAt,
+		BillingCalendar:      billingCalendarToProto(est.BillingCalendar),
+		Tax:                  estimationTaxToProto(est.Tax),
+		Agreement:            agreementSummaryToProto(est.Agreement),
+		Trace:                costTraceToProto(est.Trace),
+	}
+}
+
+func protoToEstimation(proto *pricingv1.CostEstimation) (*entity.CostEstimation, error) {
+	if proto == nil {
+		return nil, nil
+	}
+
+	// Amounts are converted through toMoney, which keeps the first invalid one
+	var moneyErr error
+	toMoney := func(m *pricingv1.Money) entity.Money {
+		money, err := protoToMoney(m)
+		if err != nil && moneyErr == nil {
+			moneyErr = err
+		}
+		return money
+	}
+
+	estimation := &entity.CostEstimation{
+		ID:                   proto.GetId(),
+		ProjectID:            proto.GetProjectId(),
+		OrganizationID:       proto.GetOrganizationId(),
+		MinMonthlyCost:       toMoney(proto.GetMinMonthlyCost()),
+		MaxMonthlyCost:       toMoney(proto.GetMaxMonthlyCost()),
+		EstimatedMonthlyCost: toMoney(proto.GetEstimatedMonthlyCost()),
+		RuntimeCosts:         make([]*entity.RuntimeCost, 0, len(proto.GetRuntimeCosts())),
+		AddonCosts:           make([]*entity.AddonCost, 0, len(proto.GetAddonCosts())),
+		CatalogVersion:       proto.GetCatalogVersion(),
+	}
+	if proto.GetPricedAt() != nil {
+		estimation.PricedAt = proto.GetPricedAt().AsTime()
+	}
+	calendar, err := protoToBillingCalendar(proto.GetBillingCalendar())
+	if err != nil {
+		return nil, err
+	}
+	estimation.BillingCalendar = calendar
+	estimation.Tax = protoToEstimationTax(proto.GetTax(), toMoney)
+	estimation.Agreement = protoToAgreementSummary(proto.GetAgreement(), toMoney)
+
+	for _, rc := range proto.GetRuntimeCosts() {
+		runtimeCost := &entity.RuntimeCost{
+			RuntimeID:             rc.GetRuntimeId(),
+			Name:                  rc.GetName(),
+			MinCost:               toMoney(rc.GetMinCost()),
+			MaxCost:               toMoney(rc.GetMaxCost()),
+			BaseCost:              toMoney(rc.GetBaseCost()),
+			ScalingCost:           toMoney(rc.GetScalingCost()),
+			UnitPrice:             toMoney(rc.GetUnitPrice()),
+			EstimatedCost:         toMoney(rc.GetEstimatedCost()),
+			ScalingHours:          rc.GetScalingHours(),
+			AverageLoadLevel:      rc.GetAverageLoadLevel(),
+			ScalingHoursByProfile: rc.GetScalingHoursByProfile(),
+			Tax:                   protoToCostRangeTax(rc.GetTax(), toMoney),
+			ListCost:              toMoney(rc.GetListCost()),
+		}
+		if rc.GetSpec() != nil {
+			spec, err := protoToRuntimeSpec(rc.GetSpec())
+			if err != nil {
+				return nil, err
+			}
+			runtimeCost.Spec = spec
+		}
+		estimation.RuntimeCosts = append(estimation.RuntimeCosts, runtimeCost)
+	}
+
+	for _, ac := range proto.GetAddonCosts() {
+		addonCost := &entity.AddonCost{
+			AddonID:   ac.GetAddonId(),
+			Name:      ac.GetName(),
+			Cost:      toMoney(ac.GetCost()),
+			UnitPrice: toMoney(ac.GetUnitPrice()),
+			UsageCost: toMoney(ac.GetUsageCost()),
+			ListCost:  toMoney(ac.GetListCost()),
+		}
+		if ac.GetTax() != nil {
+			tax := protoToTaxedAmount(ac.GetTax(), toMoney)
+			addonCost.Tax = &tax
+		}
+		for _, uc := range ac.GetUsageCosts() {
+			addonCost.UsageCosts = append(addonCost.UsageCosts, &entity.UsageMetricCost{
+				MetricID:         uc.GetMetricId(),
+				MetricName:       uc.GetMetricName(),
+				Value:            uc.GetValue(),
+				Unit:             uc.GetUnit(),
+				FreeQuotaApplied: uc.GetFreeQuotaApplied(),
+				Cost:             toMoney(uc.GetCost()),
+			})
+		}
+		if ac.GetSpec() != nil {
+			addonCost.Spec = protoToAddonSpec(ac.GetSpec())
+		}
+		estimation.AddonCosts = append(estimation.AddonCosts, addonCost)
+	}
+
+	if moneyErr != nil {
+		return nil, moneyErr
+	}
+
+	return estimation, nil
+}
+
+func moneyToProto(m entity.Money) *pricingv1.Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+
+	return &pricingv1.Money{
+		CurrencyCode: currency,
+		Nanos:        m.Nanos,
+	}
+}
+
+// protoToMoney converts a proto amount, defaulting to zero in the default
+// currency. Only the default currency is supported.
+func protoToMoney(proto *pricingv1.Money) (entity.Money, error) {
+	currency := proto.GetCurrencyCode()
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	if currency != entity.DefaultCurrency {
+		return entity.Money{}, fmt.Errorf("unsupported currency %q, amounts must be in %s", currency, entity.DefaultCurrency)
+	}
+
+	return entity.Money{
+		Nanos:    proto.GetNanos(),
+		Currency: currency,
+	}, nil
+}
+
+func protoToRuntimeSpecs(protos []*pricingv1.RuntimeSpec) ([]*entity.RuntimeSpec, error) {
+	specs := make([]*entity.RuntimeSpec, 0, len(protos))
+	for i, p := range protos {
+		spec, err := protoToRuntimeSpec(p)
+		if err != nil {
+			return nil, validation.Errors{"RuntimeSpecs": validation.Errors{strconv.Itoa(i): err}}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func protoToRuntimeSpec(proto *pricingv1.RuntimeSpec) (*entity.RuntimeSpec, error) {
+	spec := &entity.RuntimeSpec{
+		InstanceType:    proto.GetInstanceType(),
+		FlavorName:      proto.GetFlavorName(),
+		MinInstances:    proto.GetMinInstances(),
+		MaxInstances:    proto.GetMaxInstances(),
+		ZoneID:          proto.GetZoneId(),
+		ScalingEnabled:  proto.GetScalingEnabled(),
+		ScalingProfiles: make([]*entity.ScalingProfile, 0, len(proto.GetScalingProfiles())),
+	}
+
+	if b := proto.GetBaselineConfig(); b != nil {
+		spec.Baseline = entity.NewBaselineConfig(b.GetInstances(), b.GetFlavorName())
+	}
+
+	for _, p := range proto.GetScalingProfiles() {
+		spec.ScalingProfiles = append(spec.ScalingProfiles, protoToScalingProfile(p))
+	}
+
+	if ws := proto.GetWeeklySchedule(); ws != nil {
+		if len(ws.GetHours()) != entity.HoursPerWeek {
+			return nil, validation.Errors{"Schedule": validation.Errors{
+				"Hours": validation.NewError("validation_schedule_hours", fmt.Sprintf("must have %d hours, got %d", entity.HoursPerWeek, len(ws.GetHours()))),
+			}}
+		}
+
+		var schedule entity.WeeklySchedule
+		for i, h := range ws.GetHours() {
+			schedule[i/entity.HoursPerDay][i%entity.HoursPerDay] = entity.HourlyConfig{
+				ProfileID: h.GetProfileId(),
+				LoadLevel: h.GetLoadLevel(),
+			}
+		}
+		spec.Schedule = &schedule
+	}
+
+	return spec, nil
+}
+
+func protoToScalingProfile(proto *pricingv1.ScalingProfile) *entity.ScalingProfile {
+	return &entity.ScalingProfile{
+		ID:            proto.GetId(),
+		Name:          proto.GetName(),
+		MinInstances:  proto.GetMinInstances(),
+		MaxInstances:  proto.GetMaxInstances(),
+		MinFlavorName: proto.GetMinFlavorName(),
+		MaxFlavorName: proto.GetMaxFlavorName(),
+		Enabled:       proto.GetEnabled(),
+	}
+}
+
+func runtimeSpecToProto(spec *entity.RuntimeSpec) *pricingv1.RuntimeSpec {
+	if spec == nil {
+		return nil
+	}
+
+	proto := &pricingv1.RuntimeSpec{
+		InstanceType:    spec.InstanceType,
+		FlavorName:      spec.FlavorName,
+		MinInstances:    spec.MinInstances,
+		MaxInstances:    spec.MaxInstances,
+		ZoneId:          spec.ZoneID,
+		ScalingEnabled:  spec.ScalingEnabled,
+		ScalingProfiles: make([]*pricingv1.ScalingProfile, 0, len(spec.ScalingProfiles)),
+	}
+
+	if spec.Baseline != nil {
+		proto.BaselineConfig = &pricingv1.BaselineConfig{
+			Instances:  spec.Baseline.Instances,
+			FlavorName: spec.Baseline.FlavorName,
+		}
+	}
+
+	for _, p := range spec.ScalingProfiles {
+		proto.ScalingProfiles = append(proto.ScalingProfiles, &pricingv1.ScalingProfile{
+			Id:            p.ID,
+			Name:          p.Name,
+			MinInstances:  p.MinInstances,
+			MaxInstances:  p.MaxInstances,
+			MinFlavorName: p.MinFlavorName,
+			MaxFlavorName: p.MaxFlavorName,
+			Enabled:       p.Enabled,
+		})
+	}
+
+	if spec.Schedule != nil {
+		hours := make([]*pricingv1.HourlyConfig, 0, entity.HoursPerWeek)
+		for _, day := range spec.Schedule {
+			for _, slot := range day {
+				hours = append(hours, &pricingv1.HourlyConfig{
+					ProfileId: slot.ProfileID,
+					LoadLevel: slot.LoadLevel,
+				})
+			}
+		}
+		proto.WeeklySchedule = &pricingv1.WeeklySchedule{Hours: hours}
+	}
+
+	return proto
+}
+
+func protoToBillingCalendar(proto *pricingv1.BillingCalendar) (*entity.BillingCalendar, error) {
+	if proto == nil {
+		return nil, nil
+	}
+
+	calendar := &entity.BillingCalendar{
+		Year:  int(proto.GetYear()),
+		Month: time.Month(proto.GetMonth()),
+	}
+
+	switch proto.GetKind() {
+	case pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_FIXED_720:
+		calendar.Kind = entity.BillingCalendarFixed720
+	case pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_FIXED_730:
+		calendar.Kind = entity.BillingCalendarFixed730
+	case pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_CALENDAR_MONTH:
+		calendar.Kind = entity.BillingCalendarCalendarMonth
+	}
+
+	if calendar.Month < 0 || calendar.Month > time.December {
+		return nil, fmt.Errorf("invalid billing calendar month %d", proto.GetMonth())
+	}
+	if calendar.Month != 0 && calendar.Year == 0 {
+		return nil, errors.New("billing calendar year is required with a month")
+	}
+
+	if proto.GetTimeZone() != "" {
+		location, err := time.LoadLocation(proto.GetTimeZone())
+		if err != nil {
+			return nil, fmt.Errorf("invalid billing calendar time zone: %w", err)
+		}
+		calendar.Location = location
+	}
+
+	return calendar, nil
+}
+
+func billingCalendarToProto(calendar *entity.BillingCalendar) *pricingv1.BillingCalendar {
+	if calendar == nil {
+		return nil
+	}
+
+	proto := &pricingv1.BillingCalendar{
+		Year:          int32(calendar.Year),
+		Month:         int32(calendar.Month),
+		HoursPerMonth: calendar.HoursPerMonth(),
+	}
+	if calendar.Location != nil {
+		proto.TimeZone = calendar.Location.String()
+	}
+
+	switch calendar.Kind {
+	case entity.BillingCalendarFixed720:
+		proto.Kind = pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_FIXED_720
+	case entity.BillingCalendarFixed730:
+		proto.Kind = pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_FIXED_730
+	case entity.BillingCalendarCalendarMonth:
+		proto.Kind = pricingv1.BillingCalendarKind_BILLING_CALENDAR_KIND_CALENDAR_MONTH
+	}
+
+	return proto
+}
+
+func protoToAddonSpecs(protos []*pricingv1.AddonSpec) []*entity.AddonSpec {
+	specs := make([]*entity.AddonSpec, 0, len(protos))
+	for _, p := range protos {
+		specs = append(specs, protoToAddonSpec(p))
+	}
+	return specs
+}
+
+func protoToAddonSpec(proto *pricingv1.AddonSpec) *entity.AddonSpec {
+	spec := &entity.AddonSpec{
+		ProviderID: proto.GetProviderId(),
+		PlanID:     proto.GetPlanId(),
+		ZoneID:     proto.GetZoneId(),
+	}
+	for _, e := range proto.GetUsageEstimates() {
+		spec.UsageEstimates = append(spec.UsageEstimates, &entity.UsageEstimate{
+			MetricID: e.GetMetricId(),
+			Value:    e.GetValue(),
+		})
+	}
+	return spec
+}
+
+func addonSpecToProto(spec *entity.AddonSpec) *pricingv1.AddonSpec {
+	if spec == nil {
+		return nil
+	}
+
+	usageEstimates := make([]*pricingv1.UsageEstimate, 0, len(spec.UsageEstimates))
+	for _, e := range spec.UsageEstimates {
+		usageEstimates = append(usageEstimates, &pricingv1.UsageEstimate{
+			MetricId: e.MetricID,
+			Value:    e.Value,
+		})
+	}
+
+	return &pricingv1.AddonSpec{
+		ProviderId:     spec.ProviderID,
+		PlanId:         spec.PlanID,
+		ZoneId:         spec.ZoneID,
+		UsageEstimates: usageEstimates,
+	}
+}
+
+func usageMetricCostsToProto(costs []*entity.UsageMetricCost) []*pricingv1.UsageMetricCost {
+	protoCosts := make([]*pricingv1.UsageMetricCost, 0, len(costs))
+	for _, c := range costs {
+		protoCosts = append(protoCosts, &pricingv1.UsageMetricCost{
+			MetricId:         c.MetricID,
+			MetricName:       c.MetricName,
+			Value:            c.Value,
+			Unit:             c.Unit,
+			FreeQuotaApplied: c.FreeQuotaApplied,
+			Cost:             moneyToProto(c.Cost),
+		})
+	}
+	return protoCosts
+}
+
+func estimationDriftToProto(d *entity.EstimationDrift) *pricingv1.EstimationDrift {
+	runtimeDrifts := make([]*pricingv1.RuntimeCostDrift, 0, len(d.RuntimeDrifts))
+	for _, rd := range d.RuntimeDrifts {
+		runtimeDrifts = append(runtimeDrifts, &pricingv1.RuntimeCostDrift{
+			RuntimeId:     rd.RuntimeID,
+			Name:          rd.Name,
+			OldUnitPrice:  moneyToProto(rd.OldUnitPrice),
+			NewUnitPrice:  moneyToProto(rd.NewUnitPrice),
+			OldMinCost:    moneyToProto(rd.OldMinCost),
+			NewMinCost:    moneyToProto(rd.NewMinCost),
+			OldMaxCost:    moneyToProto(rd.OldMaxCost),
+			NewMaxCost:    moneyToProto(rd.NewMaxCost),
+			MinCostDelta:  moneyToProto(rd.MinCostDelta()),
+			MaxCostDelta:  moneyToProto(rd.MaxCostDelta()),
+			OldListCost:   moneyToProto(rd.OldListCost),
+			NewListCost:   moneyToProto(rd.NewListCost),
+			ListCostDelta: moneyToProto(rd.ListCostDelta()),
+			Unavailable:   rd.Unavailable,
+		})
+	}
+
+	addonDrifts := make([]*pricingv1.AddonCostDrift, 0, len(d.AddonDrifts))
+	for _, ad := range d.AddonDrifts {
+		addonDrifts = append(addonDrifts, &pricingv1.AddonCostDrift{
+			AddonId:       ad.AddonID,
+			Name:          ad.Name,
+			OldUnitPrice:  moneyToProto(ad.OldUnitPrice),
+			NewUnitPrice:  moneyToProto(ad.NewUnitPrice),
+			OldCost:       moneyToProto(ad.OldCost),
+			NewCost:       moneyToProto(ad.NewCost),
+			CostDelta:     moneyToProto(ad.CostDelta()),
+			OldListCost:   moneyToProto(ad.OldListCost),
+			NewListCost:   moneyToProto(ad.NewListCost),
+			ListCostDelta: moneyToProto(ad.ListCostDelta()),
+			Unavailable:   ad.Unavailable,
+		})
+	}
+
+	return &pricingv1.EstimationDrift{
+		EstimationId:         d.EstimationID,
+		FromCatalogVersion:   d.FromCatalogVersion,
+		ToCatalogVersion:     d.ToCatalogVersion,
+		RuntimeDrifts:        runtimeDrifts,
+		AddonDrifts:          addonDrifts,
+		MinMonthlyCostDelta:  moneyToProto(d.MinMonthlyCostDelta()),
+		MaxMonthlyCostDelta:  moneyToProto(d.MaxMonthlyCostDelta()),
+		ListMonthlyCostDelta: moneyToProto(d.ListMonthlyCostDelta()),
+		AgreementChanged:     d.AgreementChanged,
+	}
+}
+
+func protoToWeeklyLoadModel(proto *pricingv1.RuntimeLoadModel) (*entity.WeeklyLoadModel, error) {
+	if proto.GetDefaultDistribution() == nil && len(proto.GetSlots()) == 0 {
+		return nil, nil
+	}
+
+	var model entity.WeeklyLoadModel
+	if proto.GetDefaultDistribution() != nil {
+		d, err := protoToLoadDistribution(proto.GetDefaultDistribution())
+		if err != nil {
+			return nil, err
+		}
+		for day := range model {
+			for hour := range model[day] {
+				model[day][hour] = d
+			}
+		}
+	}
+
+	for _, slot := range proto.GetSlots() {
+		day, hour := slot.GetDay(), slot.GetHour()
+		if day < 0 || day >= entity.DaysPerWeek || hour < 0 || hour >= entity.HoursPerDay {
+			return nil, fmt.Errorf("invalid load distribution slot: day %d hour %d", day, hour)
+		}
+		d, err := protoToLoadDistribution(slot.GetDistribution())
+		if err != nil {
+			return nil, err
+		}
+		model[day][hour] = d
+	}
+
+	return &model, nil
+}
+
+func protoToLoadDistribution(proto *pricingv1.LoadDistribution) (*entity.LoadDistribution, error) {
+	var d entity.LoadDistribution
+	if len(proto.GetWeights()) != len(d) {
+		return nil, fmt.Errorf("load distribution must have %d weights, got %d", len(d), len(proto.GetWeights()))
+	}
+	copy(d[:], proto.GetWeights())
+	return &d, nil
+}
+
+func costSimulationToProto(s *entity.CostSimulation) *pricingv1.CostSimulation {
+	histogram := make([]*pricingv1.CostHistogramBucket, 0, len(s.Histogram))
+	for _, b := range s.Histogram {
+		histogram = append(histogram, &pricingv1.CostHistogramBucket{
+			LowerBound: moneyToProto(b.LowerBound),
+			UpperBound: moneyToProto(b.UpperBound),
+			Count:      int32(b.Count),
+		})
+	}
+
+	return &pricingv1.CostSimulation{
+		Iterations: int32(s.Iterations),
+		Seed:       s.Seed,
+		Mean:       moneyToProto(s.Mean),
+		Min:        moneyToProto(s.Min),
+		Max:        moneyToProto(s.Max),
+		P50:        moneyToProto(s.P50),
+		P90:        moneyToProto(s.P90),
+		P99:        moneyToProto(s.P99),
+		Histogram:  histogram,
+	}
+}
+
+func costProjectionToProto(p *entity.CostProjection) *pricingv1.CostProjection {
+	months := make([]*pricingv1.MonthlyCostProjection, 0, len(p.Months))
+	for _, m := range p.Months {
+		months = append(months, &pricingv1.MonthlyCostProjection{
+			Year:          int32(m.Year),
+			Month:         int32(m.Month),
+			Hours:         m.Hours,
+			TrafficFactor: m.TrafficFactor,
+			MinCost:       moneyToProto(m.MinCost),
+			ExpectedCost:  moneyToProto(m.ExpectedCost),
+			MaxCost:       moneyToProto(m.MaxCost),
+		})
+	}
+
+	return &pricingv1.CostProjection{
+		EstimationId:      p.EstimationID,
+		MonthlyGrowthRate: p.MonthlyGrowthRate,
+		Months:            months,
+		TotalMinCost:      moneyToProto(p.TotalMinCost),
+		TotalExpectedCost: moneyToProto(p.TotalExpectedCost),
+		TotalMaxCost:      moneyToProto(p.TotalMaxCost),
+	}
+}
+
+func costHeatmapToProto(m *entity.CostHeatmap) *pricingv1.CostHeatmap {
+	runtimes := make([]*pricingv1.RuntimeCostHeatmap, 0, len(m.Runtimes))
+	for _, rt := range m.Runtimes {
+		hours := make([]*pricingv1.CostHeatmapCell, 0, entity.HoursPerWeek)
+		for day := range rt.Hours {
+			for _, cell := range rt.Hours[day] {
+				hours = append(hours, &pricingv1.CostHeatmapCell{
+					ProfileId:  cell.ProfileID,
+					LoadLevel:  cell.LoadLevel,
+					FlavorName: cell.FlavorName,
+					Instances:  cell.Instances,
+					HourlyCost: moneyToProto(cell.HourlyCost),
+				})
+			}
+		}
+		runtimes = append(runtimes, &pricingv1.RuntimeCostHeatmap{
+			InstanceType: rt.InstanceType,
+			Hours:        hours,
+			WeeklyCost:   moneyToProto(rt.WeeklyCost),
+		})
+	}
+
+	hourlyCosts := make([]*pricingv1.Money, 0, entity.HoursPerWeek)
+	for day := range m.HourlyCosts {
+		for _, cost := range m.HourlyCosts[day] {
+			hourlyCosts = append(hourlyCosts, moneyToProto(cost))
+		}
+	}
+
+	return &pricingv1.CostHeatmap{
+		Runtimes:    runtimes,
+		HourlyCosts: hourlyCosts,
+		WeeklyCost:  moneyToProto(m.WeeklyCost),
+	}
+}
+
+func budgetToProto(b *entity.Budget) *pricingv1.Budget {
+	return &pricingv1.Budget{
+		OrganizationId:    b.OrganizationID,
+		ProjectId:         b.ProjectID,
+		MonthlyLimit:      moneyToProto(b.MonthlyLimit),
+		WarningThreshold:  b.WarningThreshold,
+		CriticalThreshold: b.CriticalThreshold,
+		UpdatedAt:         timestamppb.New(b.UpdatedAt),
+	}
+}
+
+func budgetEvaluationToProto(e *entity.BudgetEvaluation) *pricingv1.BudgetEvaluation {
+	return &pricingv1.BudgetEvaluation{
+		Budget:        budgetToProto(e.Budget),
+		EstimationIds: e.EstimationIDs,
+		Min:           budgetFigureToProto(e.Min),
+		Expected:      budgetFigureToProto(e.Expected),
+		Max:           budgetFigureToProto(e.Max),
+	}
+}
+
+func budgetFigureToProto(f *entity.BudgetFigure) *pricingv1.BudgetFigure {
+	return &pricingv1.BudgetFigure{
+		Cost:        moneyToProto(f.Cost),
+		Headroom:    moneyToProto(f.Headroom),
+		Utilization: f.Utilization,
+		Status:      budgetStatusToProto(f.Status),
+	}
+}
+
+func budgetAlertsToProto(alerts []*entity.BudgetAlert) []*pricingv1.BudgetAlert {
+	protos := make([]*pricingv1.BudgetAlert, 0, len(alerts))
+	for _, a := range alerts {
+		protos = append(protos, &pricingv1.BudgetAlert{
+			Id:             a.ID,
+			OrganizationId: a.OrganizationID,
+			ProjectId:      a.ProjectID,
+			EstimationId:   a.EstimationID,
+			Status:         budgetStatusToProto(a.Status),
+			Threshold:      a.Threshold,
+			ExpectedCost:   moneyToProto(a.ExpectedCost),
+			MonthlyLimit:   moneyToProto(a.MonthlyLimit),
+			CreatedAt:      timestamppb.New(a.CreatedAt),
+		})
+	}
+	return protos
+}
+
+func budgetStatusToProto(s entity.BudgetStatus) pricingv1.BudgetStatus {
+	switch s {
+	case entity.BudgetStatusOK:
+		return pricingv1.BudgetStatus_BUDGET_STATUS_OK
+	case entity.BudgetStatusWarning:
+		return pricingv1.BudgetStatus_BUDGET_STATUS_WARNING
+	case entity.BudgetStatusCritical:
+		return pricingv1.BudgetStatus_BUDGET_STATUS_CRITICAL
+	default:
+		return pricingv1.BudgetStatus_BUDGET_STATUS_UNSPECIFIED
+	}
+}
+
+func taxProfileToProto(p *entity.TaxProfile) *pricingv1.TaxProfile {
+	return &pricingv1.TaxProfile{
+		OrganizationId:  p.OrganizationID,
+		Country:         p.Country,
+		VatNumber:       p.VATNumber,
+		Exempt:          p.Exempt,
+		ExemptionReason: p.ExemptionReason,
+		UpdatedAt:       timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func taxedAmountToProto(a entity.TaxedAmount) *pricingv1.TaxedAmount {
+	return &pricingv1.TaxedAmount{
+		Net:   moneyToProto(a.Net),
+		Tax:   moneyToProto(a.Tax),
+		Gross: moneyToProto(a.Gross),
+	}
+}
+
+func taxedAmountPtrToProto(a *entity.TaxedAmount) *pricingv1.TaxedAmount {
+	if a == nil {
+		return nil
+	}
+	return taxedAmountToProto(*a)
+}
+
+func costRangeTaxToProto(t *entity.CostRangeTax) *pricingv1.CostRangeTax {
+	if t == nil {
+		return nil
+	}
+	return &pricingv1.CostRangeTax{
+		Min:       taxedAmountToProto(t.Min),
+		Estimated: taxedAmountToProto(t.Estimated),
+		Max:       taxedAmountToProto(t.Max),
+	}
+}
+
+func estimationTaxToProto(t *entity.EstimationTax) *pricingv1.EstimationTax {
+	if t == nil {
+		return nil
+	}
+
+	proto := &pricingv1.EstimationTax{
+		Min:       taxedAmountToProto(t.Min),
+		Estimated: taxedAmountToProto(t.Estimated),
+		Max:       taxedAmountToProto(t.Max),
+	}
+	if a := t.Assessment; a != nil {
+		proto.Country = a.Country
+		proto.VatNumber = a.VATNumber
+		proto.Treatment = taxTreatmentToProto(a.Treatment)
+		proto.Rate = a.Rate
+		proto.Mention = a.Mention
+	}
+	return proto
+}
+
+func protoToTaxedAmount(proto *pricingv1.TaxedAmount, toMoney func(*pricingv1.Money) entity.Money) entity.TaxedAmount {
+	return entity.TaxedAmount{
+		Net:   toMoney(proto.GetNet()),
+		Tax:   toMoney(proto.GetTax()),
+		Gross: toMoney(proto.GetGross()),
+	}
+}
+
+func protoToCostRangeTax(proto *pricingv1.CostRangeTax, toMoney func(*pricingv1.Money) entity.Money) *entity.CostRangeTax {
+	if proto == nil {
+		return nil
+	}
+	return &entity.CostRangeTax{
+		Min:       protoToTaxedAmount(proto.GetMin(), toMoney),
+		Estimated: protoToTaxedAmount(proto.GetEstimated(), toMoney),
+		Max:       protoToTaxedAmount(proto.GetMax(), toMoney),
+	}
+}
+
+func protoToEstimationTax(proto *pricingv1.EstimationTax, toMoney func(*pricingv1.Money) entity.Money) *entity.EstimationTax {
+	if proto == nil {
+		return nil
+	}
+	return &entity.EstimationTax{
+		Assessment: &entity.TaxAssessment{
+			Country:   proto.GetCountry(),
+			VATNumber: proto.GetVatNumber(),
+			Treatment: protoToTaxTreatment(proto.GetTreatment()),
+			Rate:      proto.GetRate(),
+			Mention:   proto.GetMention(),
+		},
+		CostRangeTax: entity.CostRangeTax{
+			Min:       protoToTaxedAmount(proto.GetMin(), toMoney),
+			Estimated: protoToTaxedAmount(proto.GetEstimated(), toMoney),
+			Max:       protoToTaxedAmount(proto.GetMax(), toMoney),
+		},
+	}
+}
+
+func taxTreatmentToProto(t entity.TaxTreatment) pricingv1.TaxTreatment {
+	switch t {
+	case entity.TaxTreatmentStandard:
+		return pricingv1.TaxTreatment_TAX_TREATMENT_STANDARD
+	case entity.TaxTreatmentReverseCharge:
+		return pricingv1.TaxTreatment_TAX_TREATMENT_REVERSE_CHARGE
+	case entity.TaxTreatmentExempt:
+		return pricingv1.TaxTreatment_TAX_TREATMENT_EXEMPT
+	case entity.TaxTreatmentOutOfScope:
+		return pricingv1.TaxTreatment_TAX_TREATMENT_OUT_OF_SCOPE
+	default:
+		return pricingv1.TaxTreatment_TAX_TREATMENT_UNSPECIFIED
+	}
+}
+
+func protoToTaxTreatment(t pricingv1.TaxTreatment) entity.TaxTreatment {
+	switch t {
+	case pricingv1.TaxTreatment_TAX_TREATMENT_STANDARD:
+		return entity.TaxTreatmentStandard
+	case pricingv1.TaxTreatment_TAX_TREATMENT_REVERSE_CHARGE:
+		return entity.TaxTreatmentReverseCharge
+	case pricingv1.TaxTreatment_TAX_TREATMENT_EXEMPT:
+		return entity.TaxTreatmentExempt
+	case pricingv1.TaxTreatment_TAX_TREATMENT_OUT_OF_SCOPE:
+		return entity.TaxTreatmentOutOfScope
+	default:
+		return ""
+	}
+}
+
+func pricingAgreementToProto(a *entity.PricingAgreement) *pricingv1.PricingAgreement {
+	overrides := make([]*pricingv1.FlavorPriceOverride, 0, len(a.FlavorPriceOverrides))
+	for _, o := range a.FlavorPriceOverrides {
+		overrides = append(overrides, &pricingv1.FlavorPriceOverride{
+			InstanceType: o.InstanceType,
+			FlavorName:   o.FlavorName,
+			PricePerHour: moneyToProto(o.PricePerHour),
+		})
+	}
+
+	return &pricingv1.PricingAgreement{
+		OrganizationId:       a.OrganizationID,
+		CreditBalance:        moneyToProto(a.CreditBalance),
+		MonthlyCommitment:    moneyToProto(a.MonthlyCommitment),
+		DiscountPercent:      a.DiscountPercent,
+		FlavorPriceOverrides: overrides,
+		UpdatedAt:            timestamppb.New(a.UpdatedAt),
+	}
+}
+
+func protoToFlavorPriceOverrides(protos []*pricingv1.FlavorPriceOverride) ([]*entity.FlavorPriceOverride, error) {
+	overrides := make([]*entity.FlavorPriceOverride, 0, len(protos))
+	for _, p := range protos {
+		price, err := protoToMoney(p.GetPricePerHour())
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, &entity.FlavorPriceOverride{
+			InstanceType: p.GetInstanceType(),
+			FlavorName:   p.GetFlavorName(),
+			PricePerHour: price,
+		})
+	}
+	return overrides, nil
+}
+
+func agreementSummaryToProto(s *entity.AgreementSummary) *pricingv1.AgreementSummary {
+	if s == nil {
+		return nil
+	}
+
+	return &pricingv1.AgreementSummary{
+		ListMonthlyCost:    moneyToProto(s.ListMonthlyCost),
+		DiscountAmount:     moneyToProto(s.DiscountAmount),
+		DiscountPercent:    s.DiscountPercent,
+		BilledMonthlyCost:  moneyToProto(s.BilledMonthlyCost),
+		MonthlyCommitment:  moneyToProto(s.MonthlyCommitment),
+		CreditBalance:      moneyToProto(s.CreditBalance),
+		CreditRunwayMonths: s.CreditRunwayMonths,
+	}
+}
+
+func costTraceToProto(t *entity.CostTrace) *pricingv1.CostTrace {
+	if t == nil {
+		return nil
+	}
+
+	runtimes := make([]*pricingv1.RuntimeCostTrace, 0, len(t.Runtimes))
+	for _, rt := range t.Runtimes {
+		unitPrices := make([]*pricingv1.UnitPriceTrace, 0, len(rt.UnitPrices))
+		for _, p := range rt.UnitPrices {
+			unitPrices = append(unitPrices, &pricingv1.UnitPriceTrace{
+				FlavorName:       p.FlavorName,
+				PricePerHour:     moneyToProto(p.PricePerHour),
+				ListPricePerHour: moneyToProto(p.ListPricePerHour),
+			})
+		}
+		loadLevels := make([]*pricingv1.LoadLevelTrace, 0, len(rt.LoadLevels))
+		for _, l := range rt.LoadLevels {
+			loadLevels = append(loadLevels, &pricingv1.LoadLevelTrace{
+				ScheduledProfileId: l.ScheduledProfileID,
+				ScheduledLoadLevel: l.ScheduledLoadLevel,
+				ProfileId:          l.ProfileID,
+				LoadLevel:          l.LoadLevel,
+				FlavorName:         l.FlavorName,
+				Instances:          l.Instances,
+				HourlyCost:         moneyToProto(l.HourlyCost),
+				HoursPerWeek:       l.HoursPerWeek,
+				WeeklyCost:         moneyToProto(l.WeeklyCost),
+			})
+		}
+		runtimes = append(runtimes, &pricingv1.RuntimeCostTrace{
+			RuntimeId:  rt.RuntimeID,
+			UnitPrices: unitPrices,
+			LoadLevels: loadLevels,
+			Steps:      traceStepsToProto(rt.Steps),
+		})
+	}
+
+	addons := make([]*pricingv1.AddonCostTrace, 0, len(t.Addons))
+	for _, at := range t.Addons {
+		usage := make([]*pricingv1.UsageMetricTrace, 0, len(at.Usage))
+		for _, u := range at.Usage {
+			tiers := make([]*pricingv1.UsageTierTrace, 0, len(u.Tiers))
+			for _, tc := range u.Tiers {
+				tiers = append(tiers, &pricingv1.UsageTierTrace{
+					MinThreshold: tc.Tier.MinThreshold,
+					MaxThreshold: tc.Tier.MaxThreshold,
+					PricePerUnit: moneyToProto(tc.Tier.PricePerUnit),
+					Quantity:     tc.Quantity,
+					Cost:         moneyToProto(tc.Cost),
+				})
+			}
+			usage = append(usage, &pricingv1.UsageMetricTrace{
+				MetricId:         u.MetricID,
+				Value:            u.Value,
+				Unit:             u.Unit,
+				FreeQuotaApplied: u.FreeQuotaApplied,
+				Tiers:            tiers,
+				Cost:             moneyToProto(u.Cost),
+			})
+		}
+		addons = append(addons, &pricingv1.AddonCostTrace{
+			AddonId: at.AddonID,
+			Usage:   usage,
+			Steps:   traceStepsToProto(at.Steps),
+		})
+	}
+
+	return &pricingv1.CostTrace{
+		HoursPerMonth: t.HoursPerMonth,
+		Runtimes:      runtimes,
+		Addons:        addons,
+		Steps:         traceStepsToProto(t.Steps),
+		Text:          t.Text(),
+	}
+}
+
+func traceStepsToProto(steps []*entity.TraceStep) []*pricingv1.TraceStep {
+	protoSteps := make([]*pricingv1.TraceStep, 0, len(steps))
+	for _, s := range steps {
+		protoSteps = append(protoSteps, &pricingv1.TraceStep{
+			Figure:      s.Figure,
+			Description: s.Description,
+			Formula:     s.Formula,
+			Amount:      moneyToProto(s.Amount),
+		})
+	}
+	return protoSteps
+}
+
+func protoToAgreementSummary(proto *pricingv1.AgreementSummary, toMoney func(*pricingv1.Money) entity.Money) *entity.AgreementSummary {
+	if proto == nil {
+		return nil
+	}
+
+	summary := &entity.AgreementSummary{
+		ListMonthlyCost:   toMoney(proto.GetListMonthlyCost()),
+		DiscountAmount:    toMoney(proto.GetDiscountAmount()),
+		DiscountPercent:   proto.GetDiscountPercent(),
+		BilledMonthlyCost: toMoney(proto.GetBilledMonthlyCost()),
+		MonthlyCommitment: toMoney(proto.GetMonthlyCommitment()),
+		CreditBalance:     toMoney(proto.GetCreditBalance()),
+	}
+	if proto.CreditRunwayMonths != nil {
+		runway := proto.GetCreditRunwayMonths()
+		summary.CreditRunwayMonths = &runway
+	}
+	return summary
+}
+
+func optimizationReportToProto(r *entity.OptimizationReport) *pricingv1.OptimizationReport {
+	suggestions := make([]*pricingv1.OptimizationSuggestion, 0, len(r.Suggestions))
+	for _, s := range r.Suggestions {
+		suggestions = append(suggestions, &pricingv1.OptimizationSuggestion{
+			Kind:                 optimizationKindToProto(s.Kind),
+			RuntimeIndex:         int32(s.RuntimeIndex),
+			InstanceType:         s.InstanceType,
+			Description:          s.Description,
+			SuggestedSpec:        runtimeSpecToProto(s.SuggestedSpec),
+			CurrentMonthlyCost:   moneyToProto(s.CurrentMonthlyCost),
+			SuggestedMonthlyCost: moneyToProto(s.SuggestedMonthlyCost),
+			MonthlySaving:        moneyToProto(s.MonthlySaving),
+			CurrentCapacity:      capacityRangeToProto(s.CurrentCapacity),
+			SuggestedCapacity:    capacityRangeToProto(s.SuggestedCapacity),
+		})
+	}
+
+	return &pricingv1.OptimizationReport{
+		Suggestions:        suggestions,
+		CurrentMonthlyCost: moneyToProto(r.CurrentMonthlyCost),
+		BestMonthlySaving:  moneyToProto(r.BestMonthlySaving),
+	}
+}
+
+func capacityRangeToProto(c entity.CapacityRange) *pricingv1.CapacityRange {
+	return &pricingv1.CapacityRange{
+		Min: &pricingv1.ResourceCapacity{Cpus: c.Min.CPUs, Mem: c.Min.Mem},
+		Max: &pricingv1.ResourceCapacity{Cpus: c.Max.CPUs, Mem: c.Max.Mem},
+	}
+}
+
+func optimizationKindToProto(k entity.OptimizationKind) pricingv1.OptimizationKind {
+	switch k {
+	case entity.OptimizationKindScalingBaseline:
+		return pricingv1.OptimizationKind_OPTIMIZATION_KIND_SCALING_BASELINE
+	case entity.OptimizationKindScaleOut:
+		return pricingv1.OptimizationKind_OPTIMIZATION_KIND_SCALE_OUT
+	case entity.OptimizationKindBusinessHoursOnly:
+		return pricingv1.OptimizationKind_OPTIMIZATION_KIND_BUSINESS_HOURS_ONLY
+	default:
+		return pricingv1.OptimizationKind_OPTIMIZATION_KIND_UNSPECIFIED
+	}
+}
+
+// protoToCalculateCostCommand converts a CalculateCost request to its command,
+// failing with an InvalidArgument error. Prefix is the path of the request in
+// the message it is part of, if any.
+func protoToCalculateCostCommand(msg *pricingv1.CalculateCostRequest, prefix string) (*command.CalculateCostCommand, error) {
+	runtimeSpecs, err := protoToRuntimeSpecs(msg.GetRuntimeSpecs())
+	if err != nil {
+		if verr := validationError(err, prefix); verr != nil {
+			return nil, verr
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	calendar, err := protoToBillingCalendar(msg.GetBillingCalendar())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return &command.CalculateCostCommand{
+		ProjectID:       msg.GetProjectId(),
+		OrganizationID:  msg.GetOrganizationId(),
+		ZoneID:          msg.GetZoneId(),
+		RuntimeSpecs:    runtimeSpecs,
+		AddonSpecs:      protoToAddonSpecs(msg.GetAddonSpecs()),
+		BillingCalendar: calendar,
+		Explain:         msg.GetExplain(),
+	}, nil
+}
+
+// calculateCostError converts an error of a cost calculation to a Connect
+// error, keeping Connect errors as they are. Prefix is the path of the
+// message the command was built from, see validationError.
+func calculateCostError(err error, prefix string) *connect.Error {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
+	}
+	if verr := validationError(err, prefix); verr != nil {
+		return verr
+	}
+	if errors.Is(err, command.ErrInvalidUsageEstimate) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, repository.ErrNotInCatalog) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, entity.ErrMoneyOverflow) {
+		return connect.NewError(connect.CodeOutOfRange, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func batchItemsToProto(items []*entity.CostEstimationBatchItem) []*pricingv1.CalculateCostBatchItem {
+	result := make([]*pricingv1.CalculateCostBatchItem, 0, len(items))
+	for _, item := range items {
+		if item.Err != nil {
+			result = append(result, &pricingv1.CalculateCostBatchItem{
+				Result: &pricingv1.CalculateCostBatchItem_Error{Error: operationErrorToProto(calculateCostError(item.Err, ""))},
+			})
+			continue
+		}
+		result = append(result, &pricingv1.CalculateCostBatchItem{
+			Result: &pricingv1.CalculateCostBatchItem_Estimation{Estimation: estimationToProto(item.Estimation)},
+		})
+	}
+	return result
+}
+
+func operationErrorToProto(err *connect.Error) *pricingv1.OperationError {
+	opErr := &pricingv1.OperationError{
+		Code:    err.Code().String(),
+		Message: err.Message(),
+	}
+	for _, detail := range err.Details() {
+		value, detailErr := detail.Value()
+		if detailErr != nil {
+			continue
+		}
+		badRequest, ok := value.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range badRequest.GetFieldViolations() {
+			opErr.FieldViolations = append(opErr.FieldViolations, &pricingv1.FieldViolation{
+				Field:       v.GetField(),
+				Description: v.GetDescription(),
+			})
+		}
+	}
+	return opErr
+}
+
+func organizationCostTotalToProto(t *entity.OrganizationCostTotal) *pricingv1.OrganizationCostTotal {
+	return &pricingv1.OrganizationCostTotal{
+		MinMonthlyCost:       moneyToProto(t.MinMonthlyCost),
+		MaxMonthlyCost:       moneyToProto(t.MaxMonthlyCost),
+		EstimatedMonthlyCost: moneyToProto(t.EstimatedMonthlyCost),
+		Tax:                  costRangeTaxToProto(t.Tax),
+		SucceededItems:       int32(t.SucceededItems),
+		FailedItems:          int32(t.FailedItems),
+	}
+}
+
+// protoToRecalculationEdit converts the edit of a session message, returned
+// with the name of its field.
+func protoToRecalculationEdit(req *pricingv1.RecalculateSessionRequest) (command.RecalculationEdit, string, error) {
+	switch edit := req.GetEdit().(type) {
+	case *pricingv1.RecalculateSessionRequest_AddRuntime:
+		spec, err := protoToEditRuntimeSpec(edit.AddRuntime.GetSpec())
+		return &command.AddRuntimeEdit{Spec: spec}, "add_runtime", err
+	case *pricingv1.RecalculateSessionRequest_UpdateRuntime:
+		spec, err := protoToEditRuntimeSpec(edit.UpdateRuntime.GetSpec())
+		return &command.UpdateRuntimeEdit{
+			RuntimeIndex: int(edit.UpdateRuntime.GetRuntimeIndex()),
+			Spec:         spec,
+		}, "update_runtime", err
+	case *pricingv1.RecalculateSessionRequest_ChangeFlavor:
+		return &command.ChangeFlavorEdit{
+			RuntimeIndex: int(edit.ChangeFlavor.GetRuntimeIndex()),
+			FlavorName:   edit.ChangeFlavor.GetFlavorName(),
+		}, "change_flavor", nil
+	case *pricingv1.RecalculateSessionRequest_PaintSchedule:
+		cells := make([]*command.ScheduleCell, 0, len(edit.PaintSchedule.GetCells()))
+		for _, c := range edit.PaintSchedule.GetCells() {
+			cells = append(cells, &command.ScheduleCell{
+				Day:  int(c.GetDay()),
+				Hour: int(c.GetHour()),
+				HourlyConfig: entity.HourlyConfig{
+					ProfileID: c.GetProfileId(),
+					LoadLevel: c.GetLoadLevel(),
+				},
+			})
+		}
+		return &command.PaintScheduleEdit{
+			RuntimeIndex: int(edit.PaintSchedule.GetRuntimeIndex()),
+			Cells:        cells,
+		}, "paint_schedule", nil
+	case *pricingv1.RecalculateSessionRequest_RemoveRuntime:
+		return &command.RemoveRuntimeEdit{RuntimeIndex: int(edit.RemoveRuntime.GetRuntimeIndex())}, "remove_runtime", nil
+	case *pricingv1.RecalculateSessionRequest_AddAddon:
+		var spec *entity.AddonSpec
+		if p := edit.AddAddon.GetSpec(); p != nil {
+			spec = protoToAddonSpec(p)
+		}
+		return &command.AddAddonEdit{Spec: spec}, "add_addon", nil
+	case *pricingv1.RecalculateSessionRequest_RemoveAddon:
+		return &command.RemoveAddonEdit{AddonIndex: int(edit.RemoveAddon.GetAddonIndex())}, "remove_addon", nil
+	default:
+		return nil, "", errors.New("edit is required")
+	}
+}
+
+// protoToEditRuntimeSpec converts the runtime spec of an edit, nil when unset.
+func protoToEditRuntimeSpec(proto *pricingv1.RuntimeSpec) (*entity.RuntimeSpec, error) {
+	if proto == nil {
+		return nil, nil
+	}
+	spec, err := protoToRuntimeSpec(proto)
+	if err != nil {
+		return nil, validation.Errors{"Spec": err}
+	}
+	return spec, nil
 }
@@ -0,0 +1,109 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// protoFieldNames maps the fields of commands, queries and entities to the
+// proto fields they are converted from, when their names differ.
+var protoFieldNames = map[string]string{
+	"Baseline": "baseline_config",
+	"Schedule": "weekly_schedule",
+}
+
+// validationError converts the validation errors of a command or query to an
+// InvalidArgument error with a google.rpc.BadRequest detail, whose field
+// violations are paths in the request message, like runtime_specs[0].flavor_name.
+// Prefix is the path of the message the command is built from, if not the
+// request itself. It returns nil for other errors.
+func validationError(err error, prefix string) *connect.Error {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	appendFieldViolations(badRequest, prefix, errs)
+
+	descriptions := make([]string, 0, len(badRequest.FieldViolations))
+	for _, v := range badRequest.FieldViolations {
+		descriptions = append(descriptions, v.Field+": "+v.Description)
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid request: %s", strings.Join(descriptions, "; ")))
+	if detail, err := connect.NewErrorDetail(badRequest); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// appendFieldViolations appends a field violation for each error, nested
+// errors being flattened into the path of their field.
+func appendFieldViolations(badRequest *errdetails.BadRequest, path string, errs validation.Errors) {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		fieldPath := joinFieldPath(path, key)
+		if nested, ok := errs[key].(validation.Errors); ok {
+			appendFieldViolations(badRequest, fieldPath, nested)
+			continue
+		}
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldPath,
+			Description: errs[key].Error(),
+		})
+	}
+}
+
+// joinFieldPath appends a field name, or an index for a repeated field, to a path.
+func joinFieldPath(path, key string) string {
+	if _, err := strconv.Atoi(key); err == nil {
+		return path + "[" + key + "]"
+	}
+
+	name, ok := protoFieldNames[key]
+	if !ok {
+		name = snakeCase(key)
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// snakeCase converts a Go field name to a proto field name, keeping
+// initialisms together: VATNumber becomes vat_number.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package agreement
+
+import (
+	"context"
+	"sync"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// MemoryRepository implements PricingAgreementRepository with in-memory storage.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	agreements map[string]*entity.PricingAgreement
+}
+
+// Ensure MemoryRepository implements PricingAgreementRepository.
+var _ repository.PricingAgreementRepository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		agreements: make(map[string]*entity.PricingAgreement),
+	}
+}
+
+// SavePricingAgreement stores the pricing agreement of an organization, replacing the previous one.
+func (r *MemoryRepository) SavePricingAgreement(ctx context.Context, agreement *entity.PricingAgreement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.agreements[agreement.OrganizationID] = r.deepCopy(agreement)
+	return nil
+}
+
+// FindPricingAgreement retrieves the pricing agreement of an organization, or nil if it has none.
+func (r *MemoryRepository) FindPricingAgreement(ctx context.Context, organizationID string) (*entity.PricingAgreement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agreement, exists := r.agreements[organizationID]
+	if !exists {
+		return nil, nil
+	}
+
+	return r.deepCopy(agreement), nil
+}
+
+// deepCopy creates a deep copy of a PricingAgreement.
+func (r *MemoryRepository) deepCopy(agreement *entity.PricingAgreement) *entity.PricingAgreement {
+	copy := *agreement
+	copy.FlavorPriceOverrides = make([]*entity.FlavorPriceOverride, len(agreement.FlavorPriceOverrides))
+	for i, o := range agreement.FlavorPriceOverrides {
+		override := *o
+		copy.FlavorPriceOverrides[i] = &override
+	}
+	return &copy
+}
@@ -0,0 +1,83 @@
+package budget
+
+import (
+	"context"
+	"sync"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// budgetKey identifies the budget of an organization or of one of its projects.
+type budgetKey struct {
+	organizationID string
+	projectID      string
+}
+
+// MemoryRepository implements BudgetRepository with in-memory storage.
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	budgets map[budgetKey]*entity.Budget
+	alerts  []*entity.BudgetAlert
+}
+
+// Ensure MemoryRepository implements BudgetRepository.
+var _ repository.BudgetRepository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		budgets: make(map[budgetKey]*entity.Budget),
+		alerts:  make([]*entity.BudgetAlert, 0),
+	}
+}
+
+// SaveBudget stores a budget, replacing the budget of the same organization and project.
+func (r *MemoryRepository) SaveBudget(ctx context.Context, budget *entity.Budget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copy := *budget
+	r.budgets[budgetKey{budget.OrganizationID, budget.ProjectID}] = &copy
+	return nil
+}
+
+// FindBudget retrieves the budget of an organization or of one of its projects.
+func (r *MemoryRepository) FindBudget(ctx context.Context, organizationID, projectID string) (*entity.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budget, exists := r.budgets[budgetKey{organizationID, projectID}]
+	if !exists {
+		return nil, nil
+	}
+
+	copy := *budget
+	return &copy, nil
+}
+
+// SaveAlert stores a budget alert.
+func (r *MemoryRepository) SaveAlert(ctx context.Context, alert *entity.BudgetAlert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copy := *alert
+	r.alerts = append(r.alerts, &copy)
+	return nil
+}
+
+// ListAlerts retrieves the budget alerts of an organization, oldest first.
+func (r *MemoryRepository) ListAlerts(ctx context.Context, organizationID string) ([]*entity.BudgetAlert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*entity.BudgetAlert
+	for _, alert := range r.alerts {
+		if alert.OrganizationID == organizationID {
+			copy := *alert
+			results = append(results, &copy)
+		}
+	}
+
+	return results, nil
+}
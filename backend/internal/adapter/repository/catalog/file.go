@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	pricingrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/pricing"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// snapshotExt is the extension of snapshot files, named after the snapshot ID.
+const snapshotExt = ".json"
+
+// FileRepository implements CatalogHistoryRepository by writing each snapshot
+// to a directory in the catalog snapshot format. The history is loaded in memory
+// when the repository is created.
+type FileRepository struct {
+	*MemoryRepository
+	dir string
+}
+
+// Ensure FileRepository implements CatalogHistoryRepository.
+var _ repository.CatalogHistoryRepository = (*FileRepository)(nil)
+
+// NewFileRepository creates a new FileRepository and loads the snapshots already in dir.
+func NewFileRepository(dir string) (*FileRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create catalog history directory: %w", err)
+	}
+
+	r := &FileRepository{
+		MemoryRepository: NewMemoryRepository(),
+		dir:              dir,
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog history directory: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != snapshotExt {
+			continue
+		}
+
+		snapshot, err := r.load(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		r.insert(snapshot)
+	}
+
+	return r, nil
+}
+
+// Save stores a catalog snapshot and returns its ID. The snapshot is written
+// to a temporary file renamed once complete, so that a failed write never
+// leaves a partial snapshot to load on restart.
+func (r *FileRepository) Save(ctx context.Context, snapshot *entity.CatalogSnapshot) (string, error) {
+	if snapshot.ID == "" {
+		snapshot.ID = uuid.New().String()
+	}
+
+	if err := r.write(snapshot); err != nil {
+		return "", err
+	}
+
+	return r.MemoryRepository.Save(ctx, snapshot)
+}
+
+func (r *FileRepository) write(snapshot *entity.CatalogSnapshot) error {
+	file, err := os.CreateTemp(r.dir, snapshot.ID+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create catalog snapshot file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := pricingrepo.EncodeSnapshot(file, snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write catalog snapshot file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), filepath.Join(r.dir, snapshot.ID+snapshotExt)); err != nil {
+		return fmt.Errorf("failed to store catalog snapshot file: %w", err)
+	}
+	return nil
+}
+
+func (r *FileRepository) load(name string) (*entity.CatalogSnapshot, error) {
+	file, err := os.Open(filepath.Join(r.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open catalog snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	snapshot, err := pricingrepo.DecodeSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	snapshot.ID = strings.TrimSuffix(name, snapshotExt)
+
+	return snapshot, nil
+}
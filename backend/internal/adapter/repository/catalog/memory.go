@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// MemoryRepository implements CatalogHistoryRepository with in-memory storage.
+// Snapshots are immutable once saved and are shared with callers as is.
+type MemoryRepository struct {
+	mu        sync.RWMutex
+	snapshots []*entity.CatalogSnapshot
+}
+
+// Ensure MemoryRepository implements CatalogHistoryRepository.
+var _ repository.CatalogHistoryRepository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		snapshots: make([]*entity.CatalogSnapshot, 0),
+	}
+}
+
+// Save stores a catalog snapshot and returns its ID.
+func (r *MemoryRepository) Save(ctx context.Context, snapshot *entity.CatalogSnapshot) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if snapshot.ID == "" {
+		snapshot.ID = uuid.New().String()
+	}
+	r.insert(snapshot)
+
+	return snapshot.ID, nil
+}
+
+// FindByID retrieves a catalog snapshot by its ID.
+func (r *MemoryRepository) FindByID(ctx context.Context, id string) (*entity.CatalogSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.snapshots {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindLatest retrieves the most recent catalog snapshot.
+func (r *MemoryRepository) FindLatest(ctx context.Context) (*entity.CatalogSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.snapshots) == 0 {
+		return nil, nil
+	}
+
+	return r.snapshots[len(r.snapshots)-1], nil
+}
+
+// FindAt retrieves the most recent catalog snapshot generated at or before t.
+func (r *MemoryRepository) FindAt(ctx context.Context, t time.Time) (*entity.CatalogSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Index of the first snapshot generated after t
+	i := sort.Search(len(r.snapshots), func(i int) bool {
+		return r.snapshots[i].GeneratedAt.After(t)
+	})
+	if i == 0 {
+		return nil, nil
+	}
+
+	return r.snapshots[i-1], nil
+}
+
+// List retrieves all catalog snapshots, oldest first.
+func (r *MemoryRepository) List(ctx context.Context) ([]*entity.CatalogSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*entity.CatalogSnapshot(nil), r.snapshots...), nil
+}
+
+// insert adds a snapshot keeping the history sorted by generation time. r.mu must be held.
+func (r *MemoryRepository) insert(snapshot *entity.CatalogSnapshot) {
+	i := sort.Search(len(r.snapshots), func(i int) bool {
+		return r.snapshots[i].GeneratedAt.After(snapshot.GeneratedAt)
+	})
+	r.snapshots = append(r.snapshots, nil)
+	copy(r.snapshots[i+1:], r.snapshots[i:])
+	r.snapshots[i] = snapshot
+}
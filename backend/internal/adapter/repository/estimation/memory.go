@@ -2,6 +2,7 @@ package estimation
 
 import (
 	"context"
+	"maps"
 	"sync"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
@@ -65,6 +66,21 @@ func (r *MemoryRepository) FindByProjectID(ctx context.Context, projectID string
 	return results, nil
 }
 
+// FindByOrganizationID retrieves all estimations for the projects of an organization.
+func (r *MemoryRepository) FindByOrganizationID(ctx context.Context, organizationID string) ([]*entity.CostEstimation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*entity.CostEstimation
+	for _, est := range r.estimations {
+		if est.OrganizationID == organizationID {
+			results = append(results, r.deepCopy(est))
+		}
+	}
+
+	return results, nil
+}
+
 // Delete removes a cost estimation by its ID.
 func (r *MemoryRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
@@ -81,28 +97,86 @@ func (r *MemoryRepository) deepCopy(est *entity.CostEstimation) *entity.CostEsti
 	}
 
 	copy := &entity.CostEstimation{
-		ID:             est.ID,
-		ProjectID:      est.ProjectID,
-		MinMonthlyCost: est.MinMonthlyCost,
-		MaxMonthlyCost: est.MaxMonthlyCost,
-		RuntimeCosts:   make([]*entity.RuntimeCost, len(est.RuntimeCosts)),
-		AddonCosts:     make([]*entity.AddonCost, len(est.AddonCosts)),
+		ID:                   est.ID,
+		ProjectID:            est.ProjectID,
+		OrganizationID:       est.OrganizationID,
+		MinMonthlyCost:       est.MinMonthlyCost,
+		MaxMonthlyCost:       est.MaxMonthlyCost,
+		EstimatedMonthlyCost: est.EstimatedMonthlyCost,
+		RuntimeCosts:         make([]*entity.RuntimeCost, len(est.RuntimeCosts)),
+		AddonCosts:           make([]*entity.AddonCost, len(est.AddonCosts)),
+		CatalogVersion:       est.CatalogVersion,
+		PricedAt:             est.PricedAt,
+	}
+	if est.BillingCalendar != nil {
+		calendar := *est.BillingCalendar
+		copy.BillingCalendar = &calendar
+	}
+	if est.Tax != nil {
+		tax := *est.Tax
+		if est.Tax.Assessment != nil {
+			assessment := *est.Tax.Assessment
+			tax.Assessment = &assessment
+		}
+		copy.Tax = &tax
+	}
+	if est.Agreement != nil {
+		agreement := *est.Agreement
+		if est.Agreement.CreditRunwayMonths != nil {
+			runway := *est.Agreement.CreditRunwayMonths
+			agreement.CreditRunwayMonths = &runway
+		}
+		copy.Agreement = &agreement
 	}
 
 	for i, rc := range est.RuntimeCosts {
 		copy.RuntimeCosts[i] = &entity.RuntimeCost{
-			RuntimeID: rc.RuntimeID,
-			Name:      rc.Name,
-			MinCost:   rc.MinCost,
-			MaxCost:   rc.MaxCost,
+			RuntimeID:        rc.RuntimeID,
+			Name:             rc.Name,
+			MinCost:          rc.MinCost,
+			MaxCost:          rc.MaxCost,
+			BaseCost:         rc.BaseCost,
+			ScalingCost:      rc.ScalingCost,
+			UnitPrice:        rc.UnitPrice,
+			EstimatedCost:    rc.EstimatedCost,
+			ScalingHours:     rc.ScalingHours,
+			AverageLoadLevel: rc.AverageLoadLevel,
+			ListCost:         rc.ListCost,
+		}
+		if rc.Spec != nil {
+			copy.RuntimeCosts[i].Spec = rc.Spec.Clone()
+		}
+		if rc.ScalingHoursByProfile != nil {
+			copy.RuntimeCosts[i].ScalingHoursByProfile = maps.Clone(rc.ScalingHoursByProfile)
+		}
+		if rc.Tax != nil {
+			tax := *rc.Tax
+			copy.RuntimeCosts[i].Tax = &tax
 		}
 	}
 
 	for i, ac := range est.AddonCosts {
 		copy.AddonCosts[i] = &entity.AddonCost{
-			AddonID: ac.AddonID,
-			Name:    ac.Name,
-			Cost:    ac.Cost,
+			AddonID:   ac.AddonID,
+			Name:      ac.Name,
+			Cost:      ac.Cost,
+			UnitPrice: ac.UnitPrice,
+			UsageCost: ac.UsageCost,
+			ListCost:  ac.ListCost,
+		}
+		if ac.Spec != nil {
+			copy.AddonCosts[i].Spec = ac.Spec.Clone()
+		}
+		if ac.Tax != nil {
+			tax := *ac.Tax
+			copy.AddonCosts[i].Tax = &tax
+		}
+		if ac.UsageCosts != nil {
+			copy.AddonCosts[i].UsageCosts = make([]*entity.UsageMetricCost, len(ac.UsageCosts))
+			for j, uc := range ac.UsageCosts {
+				usageCost := *uc
+				copy.AddonCosts[i].UsageCosts[j] = &usageCost
+			}
 		}
 	}
 
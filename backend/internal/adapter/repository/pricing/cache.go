@@ -0,0 +1,294 @@
+package pricing
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/config"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// refreshTimeout bounds a catalog fetch that is no longer tied to a caller's context.
+const refreshTimeout = 30 * time.Second
+
+// degradedTTL bounds the TTL of a catalog fetched in degraded mode, such as
+// instances priced without the billing price system, so that it is replaced
+// soon after the failure is over.
+const degradedTTL = time.Minute
+
+// CacheStats holds the counters of a CachedRepository.
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	StaleHits     uint64 `json:"stale_hits"`
+	Misses        uint64 `json:"misses"`
+	Refreshes     uint64 `json:"refreshes"`
+	RefreshErrors uint64 `json:"refresh_errors"`
+	Entries       int    `json:"entries"`
+}
+
+// CacheStatsReporter is implemented by the cached repositories.
+type CacheStatsReporter interface {
+	Stats() CacheStats
+}
+
+// CachedRepository decorates a PricingRepository with an in-memory, per-zone catalog cache.
+//
+// A catalog younger than the TTL is served as is. Past the TTL and within the
+// max-stale window it is still served while a single background refresh
+// replaces it. Older catalogs, or zones never fetched, are loaded synchronously
+// and concurrent callers for the same zone share that one fetch.
+//
+// Instances the next repository reports as degraded are cached for at most
+// degradedTTL.
+//
+// Returned instances are shared between callers and must be treated as read-only.
+type CachedRepository struct {
+	next      repository.PricingRepository
+	instances *catalogCache[[]*entity.Instance]
+}
+
+// Ensure CachedRepository implements PricingRepository.
+var _ repository.PricingRepository = (*CachedRepository)(nil)
+
+// NewCachedRepository creates a new CachedRepository around the given repository.
+func NewCachedRepository(next repository.PricingRepository, cfg *config.CatalogConfig) *CachedRepository {
+	return &CachedRepository{
+		next:      next,
+		instances: newCatalogCache[[]*entity.Instance](cfg.CacheTTL, cfg.CacheMaxStale),
+	}
+}
+
+// degradableInstanceLister is implemented by repositories that can list
+// instances in a degraded mode.
+type degradableInstanceLister interface {
+	listInstances(ctx context.Context, zoneID string) (instances []*entity.Instance, degraded bool, err error)
+}
+
+// ListInstances returns all available instances for a given zone.
+func (r *CachedRepository) ListInstances(ctx context.Context, zoneID string) ([]*entity.Instance, error) {
+	return r.instances.get(ctx, zoneID, func(ctx context.Context) ([]*entity.Instance, bool, error) {
+		if lister, ok := r.next.(degradableInstanceLister); ok {
+			return lister.listInstances(ctx, zoneID)
+		}
+		instances, err := r.next.ListInstances(ctx, zoneID)
+		return instances, false, err
+	})
+}
+
+// GetInstanceByType returns an instance of a given zone by its type.
+func (r *CachedRepository) GetInstanceByType(ctx context.Context, zoneID, instanceType string) (*entity.Instance, error) {
+	instances, err := r.ListInstances(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findInstanceByType(instances, zoneID, instanceType)
+}
+
+// Stats returns a snapshot of the cache counters.
+func (r *CachedRepository) Stats() CacheStats {
+	return r.instances.stats()
+}
+
+// addonCatalogKey is the cache key of the addon catalog, which is not zoned.
+const addonCatalogKey = "addons"
+
+// CachedAddonCatalogRepository decorates an AddonCatalogRepository with the same
+// caching policy as CachedRepository.
+type CachedAddonCatalogRepository struct {
+	next      repository.AddonCatalogRepository
+	providers *catalogCache[[]*entity.AddonProvider]
+}
+
+// Ensure CachedAddonCatalogRepository implements AddonCatalogRepository.
+var _ repository.AddonCatalogRepository = (*CachedAddonCatalogRepository)(nil)
+
+// NewCachedAddonCatalogRepository creates a new CachedAddonCatalogRepository around the given repository.
+func NewCachedAddonCatalogRepository(next repository.AddonCatalogRepository, cfg *config.CatalogConfig) *CachedAddonCatalogRepository {
+	return &CachedAddonCatalogRepository{
+		next:      next,
+		providers: newCatalogCache[[]*entity.AddonProvider](cfg.CacheTTL, cfg.CacheMaxStale),
+	}
+}
+
+// ListAddonProviders returns all released addon providers.
+func (r *CachedAddonCatalogRepository) ListAddonProviders(ctx context.Context) ([]*entity.AddonProvider, error) {
+	return r.providers.get(ctx, addonCatalogKey, complete(r.next.ListAddonProviders))
+}
+
+// Stats returns a snapshot of the cache counters.
+func (r *CachedAddonCatalogRepository) Stats() CacheStats {
+	return r.providers.stats()
+}
+
+// zoneCatalogKey is the cache key of the zone catalog.
+const zoneCatalogKey = "zones"
+
+// CachedZoneRepository decorates a ZoneRepository with the same caching policy
+// as CachedRepository.
+type CachedZoneRepository struct {
+	next  repository.ZoneRepository
+	zones *catalogCache[[]*entity.Zone]
+}
+
+// Ensure CachedZoneRepository implements ZoneRepository.
+var _ repository.ZoneRepository = (*CachedZoneRepository)(nil)
+
+// NewCachedZoneRepository creates a new CachedZoneRepository around the given repository.
+func NewCachedZoneRepository(next repository.ZoneRepository, cfg *config.CatalogConfig) *CachedZoneRepository {
+	return &CachedZoneRepository{
+		next:  next,
+		zones: newCatalogCache[[]*entity.Zone](cfg.CacheTTL, cfg.CacheMaxStale),
+	}
+}
+
+// ListZones returns all deployment zones.
+func (r *CachedZoneRepository) ListZones(ctx context.Context) ([]*entity.Zone, error) {
+	return r.zones.get(ctx, zoneCatalogKey, complete(r.next.ListZones))
+}
+
+// Stats returns a snapshot of the cache counters.
+func (r *CachedZoneRepository) Stats() CacheStats {
+	return r.zones.stats()
+}
+
+// catalogCache caches values per key with a TTL and a stale-while-revalidate window.
+type catalogCache[T any] struct {
+	ttl      time.Duration
+	maxStale time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*cacheEntry[T]
+	inflight map[string]*fetchCall[T]
+
+	hits          atomic.Uint64
+	staleHits     atomic.Uint64
+	misses        atomic.Uint64
+	refreshes     atomic.Uint64
+	refreshErrors atomic.Uint64
+}
+
+type cacheEntry[T any] struct {
+	value     T
+	fetchedAt time.Time
+	ttl       time.Duration
+}
+
+// fetchCall is a fetch in progress, shared by every caller waiting on the same key.
+type fetchCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// fetchFunc fetches a value, reporting whether it was fetched in degraded mode.
+type fetchFunc[T any] func(ctx context.Context) (value T, degraded bool, err error)
+
+// complete returns the fetch function of a repository that has no degraded mode.
+func complete[T any](fetch func(context.Context) (T, error)) fetchFunc[T] {
+	return func(ctx context.Context) (T, bool, error) {
+		value, err := fetch(ctx)
+		return value, false, err
+	}
+}
+
+func newCatalogCache[T any](ttl, maxStale time.Duration) *catalogCache[T] {
+	return &catalogCache[T]{
+		ttl:      ttl,
+		maxStale: maxStale,
+		now:      time.Now,
+		entries:  make(map[string]*cacheEntry[T]),
+		inflight: make(map[string]*fetchCall[T]),
+	}
+}
+
+// get returns the cached value for key, fetching it when missing or too old.
+func (c *catalogCache[T]) get(ctx context.Context, key string, fetch fetchFunc[T]) (T, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		age := c.now().Sub(entry.fetchedAt)
+		if age < entry.ttl {
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry.value, nil
+		}
+		if age < entry.ttl+c.maxStale {
+			// Serve stale data and revalidate in the background
+			c.startFetchLocked(ctx, key, fetch)
+			c.mu.Unlock()
+			c.staleHits.Add(1)
+			return entry.value, nil
+		}
+	}
+	call := c.startFetchLocked(ctx, key, fetch)
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// startFetchLocked joins the fetch in progress for key or starts a new one.
+// The fetch is detached from the caller's cancellation so that other waiters
+// and the cache itself still get the result. c.mu must be held.
+func (c *catalogCache[T]) startFetchLocked(ctx context.Context, key string, fetch fetchFunc[T]) *fetchCall[T] {
+	if call, ok := c.inflight[key]; ok {
+		return call
+	}
+
+	call := &fetchCall[T]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.refreshes.Add(1)
+
+	go func() {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		var degraded bool
+		call.value, degraded, call.err = fetch(fetchCtx)
+
+		c.mu.Lock()
+		if call.err == nil {
+			ttl := c.ttl
+			if degraded {
+				ttl = min(ttl, degradedTTL)
+			}
+			c.entries[key] = &cacheEntry[T]{value: call.value, fetchedAt: c.now(), ttl: ttl}
+		} else {
+			c.refreshErrors.Add(1)
+			log.Printf("catalog cache: failed to refresh %q: %v", key, call.err)
+		}
+		delete(c.inflight, key)
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return call
+}
+
+// stats returns a snapshot of the cache counters.
+func (c *catalogCache[T]) stats() CacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		StaleHits:     c.staleHits.Load(),
+		Misses:        c.misses.Load(),
+		Refreshes:     c.refreshes.Load(),
+		RefreshErrors: c.refreshErrors.Load(),
+		Entries:       entries,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -33,84 +34,150 @@ func NewCleverCloudRepository(cfg *config.CleverCloudConfig) *CleverCloudReposit
 
 // APIProduct represents a product from the Clever Cloud API.
 type APIProduct struct {
-	Type    string      `json:"type"`
-	Name    string      `json:"name"`
-	Version string      `json:"version"`
-	Flavors []APIFlavor `json:"flavors"`
+	Type         string      `json:"type"`
+	Name         string      `json:"name"`
+	Version      string      `json:"version"`
+	MaxInstances int32       `json:"maxInstances,omitempty"`
+	Flavors      []APIFlavor `json:"flavors"`
 }
 
 // APIFlavor represents a flavor from the Clever Cloud API.
 type APIFlavor struct {
-	Name  string  `json:"name"`
-	Mem   int32   `json:"mem"`
-	CPUs  int32   `json:"cpus"`
-	Price float64 `json:"price"`
+	Name    string  `json:"name"`
+	Mem     int32   `json:"mem"`
+	CPUs    int32   `json:"cpus"`
+	Price   float64 `json:"price"`
+	PriceID string  `json:"price_id"`
+	// Available defaults to true when absent.
+	Available *bool `json:"available,omitempty"`
+}
+
+// APIPriceSystem represents the billing price system from the Clever Cloud API.
+type APIPriceSystem struct {
+	Runtime []APIRuntimePrice `json:"runtime"`
+}
+
+// APIRuntimePrice represents the hourly price of a runtime flavor, keyed by its price ID.
+type APIRuntimePrice struct {
+	SlugID string  `json:"slug_id"`
+	Price  float64 `json:"price"`
 }
 
 // ListInstances returns all available instances for a given zone.
+//
+// Flavor prices from the products endpoint are known to be inaccurate, so they
+// are replaced by the billing price system ones, joined on the flavor price ID.
+// When the price system cannot be fetched, the product prices are kept.
 func (r *CleverCloudRepository) ListInstances(ctx context.Context, zoneID string) ([]*entity.Instance, error) {
+	instances, _, err := r.listInstances(ctx, zoneID)
+	return instances, err
+}
+
+// listInstances is ListInstances, also reporting whether the product prices
+// were kept because the price system could not be fetched.
+func (r *CleverCloudRepository) listInstances(ctx context.Context, zoneID string) ([]*entity.Instance, bool, error) {
+	type pricesResult struct {
+		prices map[string]float64
+		err    error
+	}
+	pricesCh := make(chan pricesResult, 1)
+	go func() {
+		prices, err := r.fetchRuntimePrices(ctx, zoneID)
+		pricesCh <- pricesResult{prices: prices, err: err}
+	}()
+
+	var apiProducts []APIProduct
 	url := fmt.Sprintf("%s/products/instances?zone_id=%s", r.config.APIURL, zoneID)
+	if err := r.getJSON(ctx, url, &apiProducts); err != nil {
+		return nil, false, fmt.Errorf("failed to fetch instances: %w", err)
+	}
+
+	prices := <-pricesCh
+	if prices.err != nil {
+		log.Printf("Failed to fetch price system for zone %s, using product prices: %v", zoneID, prices.err)
+	}
+
+	instances := make([]*entity.Instance, 0, len(apiProducts))
+	for _, p := range apiProducts {
+		instances = append(instances, productToEntity(p, prices.prices))
+	}
+
+	return instances, prices.err != nil, nil
+}
+
+// productToEntity converts an API product, replacing flavor prices found in prices by price ID.
+func productToEntity(p APIProduct, prices map[string]float64) *entity.Instance {
+	instance := entity.NewInstance(p.Type, p.Name, p.Version)
+	instance.MaxInstances = p.MaxInstances
+	for _, f := range p.Flavors {
+		price := f.Price
+		if realPrice, ok := prices[f.PriceID]; ok {
+			price = realPrice
+		}
+		flavor := entity.NewFlavor(f.Name, f.Mem, f.CPUs, entity.EUR(price), f.Available == nil || *f.Available)
+		flavor.PriceID = f.PriceID
+		instance.AddFlavor(flavor)
+	}
+	return instance
+}
 
+// fetchRuntimePrices returns the billing price system runtime prices for a zone, keyed by price ID.
+func (r *CleverCloudRepository) fetchRuntimePrices(ctx context.Context, zoneID string) (map[string]float64, error) {
+	var priceSystem APIPriceSystem
+	url := fmt.Sprintf("%s/billing/price-system?zone_id=%s", r.config.APIURL, zoneID)
+	if err := r.getJSON(ctx, url, &priceSystem); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(priceSystem.Runtime))
+	for _, p := range priceSystem.Runtime {
+		prices[p.SlugID] = p.Price
+	}
+
+	return prices, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response body into v.
+func (r *CleverCloudRepository) getJSON(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch instances: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var apiProducts []APIProduct
-	if err := json.NewDecoder(resp.Body).Decode(&apiProducts); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	instances := make([]*entity.Instance, 0, len(apiProducts))
-	for _, p := range apiProducts {
-		instance := entity.NewInstance(p.Type, p.Name, p.Version)
-		for _, f := range p.Flavors {
-			flavor := entity.NewFlavor(f.Name, f.Mem, f.CPUs, f.Price, true)
-			instance.AddFlavor(flavor)
-		}
-		instances = append(instances, instance)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return instances, nil
+	return nil
 }
 
-// GetInstanceByType returns an instance by its type.
-func (r *CleverCloudRepository) GetInstanceByType(ctx context.Context, instanceType string) (*entity.Instance, error) {
-	instances, err := r.ListInstances(ctx, "par")
+// GetInstanceByType returns an instance of a given zone by its type.
+func (r *CleverCloudRepository) GetInstanceByType(ctx context.Context, zoneID, instanceType string) (*entity.Instance, error) {
+	instances, err := r.ListInstances(ctx, zoneID)
 	if err != nil {
 		return nil, err
 	}
 
+	return findInstanceByType(instances, zoneID, instanceType)
+}
+
+// findInstanceByType looks up an instance by its type in the catalog of a zone.
+func findInstanceByType(instances []*entity.Instance, zoneID, instanceType string) (*entity.Instance, error) {
 	for _, inst := range instances {
 		if inst.Type == instanceType {
 			return inst, nil
 		}
 	}
 
-	return nil, fmt.Errorf("instance type %s not found", instanceType)
-}
-
-// GetFlavorPrice returns the hourly price for a specific instance type and flavor.
-func (r *CleverCloudRepository) GetFlavorPrice(ctx context.Context, instanceType, flavorName string) (float64, error) {
-	instance, err := r.GetInstanceByType(ctx, instanceType)
-	if err != nil {
-		return 0, err
-	}
-
-	flavor := instance.FindFlavorByName(flavorName)
-	if flavor == nil {
-		return 0, fmt.Errorf("flavor %s not found for instance type %s", flavorName, instanceType)
-	}
-
-	return flavor.PricePerHour, nil
+	return nil, fmt.Errorf("instance type %s not found in zone %s: %w", instanceType, zoneID, repository.ErrNotInCatalog)
 }
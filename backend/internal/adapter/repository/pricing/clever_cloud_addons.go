@@ -0,0 +1,91 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// addonStatusRelease is the status of addon providers that can be provisioned.
+const addonStatusRelease = "RELEASE"
+
+// Ensure CleverCloudRepository implements AddonCatalogRepository.
+var _ repository.AddonCatalogRepository = (*CleverCloudRepository)(nil)
+
+// APIAddonProvider represents an addon provider from the Clever Cloud API.
+type APIAddonProvider struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	ShortDesc string            `json:"shortDesc"`
+	LogoURL   string            `json:"logoUrl"`
+	Status    string            `json:"status"`
+	Regions   []string          `json:"regions"`
+	Plans     []APIAddonPlan    `json:"plans"`
+	Features  []APIAddonFeature `json:"features"`
+}
+
+// APIAddonPlan represents an addon plan from the Clever Cloud API.
+type APIAddonPlan struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Slug     string            `json:"slug"`
+	Price    float64           `json:"price"`
+	PriceID  string            `json:"price_id"`
+	Features []APIAddonFeature `json:"features"`
+	Zones    []string          `json:"zones"`
+}
+
+// APIAddonFeature represents an addon feature from the Clever Cloud API.
+type APIAddonFeature struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	NameCode string `json:"name_code"`
+}
+
+// ListAddonProviders returns all released addon providers.
+func (r *CleverCloudRepository) ListAddonProviders(ctx context.Context) ([]*entity.AddonProvider, error) {
+	var apiProviders []APIAddonProvider
+	url := fmt.Sprintf("%s/products/addonproviders", r.config.APIV2URL)
+	if err := r.getJSON(ctx, url, &apiProviders); err != nil {
+		return nil, fmt.Errorf("failed to fetch addon providers: %w", err)
+	}
+
+	providers := make([]*entity.AddonProvider, 0, len(apiProviders))
+	for _, p := range apiProviders {
+		if p.Status != addonStatusRelease {
+			continue
+		}
+		providers = append(providers, addonProviderToEntity(p))
+	}
+
+	return providers, nil
+}
+
+func addonProviderToEntity(p APIAddonProvider) *entity.AddonProvider {
+	provider := entity.NewAddonProvider(p.ID, p.Name, p.Status)
+	provider.ShortDesc = p.ShortDesc
+	provider.LogoURL = p.LogoURL
+	provider.Regions = append(provider.Regions, p.Regions...)
+	provider.Features = addonFeaturesToEntity(p.Features)
+
+	for _, pl := range p.Plans {
+		plan := entity.NewAddonPlan(pl.ID, pl.Name, pl.Slug, entity.EUR(pl.Price))
+		plan.PriceID = pl.PriceID
+		plan.Features = addonFeaturesToEntity(pl.Features)
+		plan.Zones = append(plan.Zones, pl.Zones...)
+		provider.AddPlan(plan)
+	}
+
+	return provider
+}
+
+func addonFeaturesToEntity(features []APIAddonFeature) []*entity.AddonFeature {
+	result := make([]*entity.AddonFeature, 0, len(features))
+	for _, f := range features {
+		result = append(result, entity.NewAddonFeature(f.Name, f.Type, f.Value, f.NameCode))
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// Ensure CleverCloudRepository implements ZoneRepository.
+var _ repository.ZoneRepository = (*CleverCloudRepository)(nil)
+
+// APIZone represents a deployment zone from the Clever Cloud API.
+type APIZone struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName"`
+	Country     string   `json:"country"`
+	CountryCode string   `json:"countryCode"`
+	City        string   `json:"city"`
+	Lat         float64  `json:"lat"`
+	Lon         float64  `json:"lon"`
+	Tags        []string `json:"tags"`
+}
+
+// ListZones returns all deployment zones.
+func (r *CleverCloudRepository) ListZones(ctx context.Context) ([]*entity.Zone, error) {
+	var apiZones []APIZone
+	url := fmt.Sprintf("%s/products/zones", r.config.APIURL)
+	if err := r.getJSON(ctx, url, &apiZones); err != nil {
+		return nil, fmt.Errorf("failed to fetch zones: %w", err)
+	}
+
+	zones := make([]*entity.Zone, 0, len(apiZones))
+	for _, z := range apiZones {
+		zones = append(zones, zoneToEntity(z))
+	}
+
+	return zones, nil
+}
+
+func zoneToEntity(z APIZone) *entity.Zone {
+	zone := entity.NewZone(z.Name, z.DisplayName, z.Country, z.CountryCode, z.City)
+	zone.Lat = z.Lat
+	zone.Lon = z.Lon
+	zone.Tags = append(zone.Tags, z.Tags...)
+	return zone
+}
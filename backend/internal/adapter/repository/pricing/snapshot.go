@@ -0,0 +1,239 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/config"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// snapshotFormatVersion is the version of the catalog snapshot file format.
+const snapshotFormatVersion = 1
+
+//go:embed snapshots/default.json
+var defaultSnapshot []byte
+
+// snapshotFile is the JSON representation of a catalog snapshot.
+// Catalog items use the same shape as the Clever Cloud API responses.
+type snapshotFile struct {
+	Version        int                     `json:"version"`
+	GeneratedAt    time.Time               `json:"generated_at"`
+	Zones          []APIZone               `json:"zones"`
+	Instances      map[string][]APIProduct `json:"instances"`
+	AddonProviders []APIAddonProvider      `json:"addon_providers"`
+}
+
+// SnapshotRepository implements the catalog repositories from a catalog snapshot,
+// without any network access.
+type SnapshotRepository struct {
+	snapshot *entity.CatalogSnapshot
+}
+
+// Ensure SnapshotRepository implements the catalog repositories.
+var (
+	_ repository.PricingRepository      = (*SnapshotRepository)(nil)
+	_ repository.AddonCatalogRepository = (*SnapshotRepository)(nil)
+	_ repository.ZoneRepository         = (*SnapshotRepository)(nil)
+)
+
+// NewSnapshotRepository creates a new SnapshotRepository serving the given snapshot.
+func NewSnapshotRepository(snapshot *entity.CatalogSnapshot) *SnapshotRepository {
+	return &SnapshotRepository{
+		snapshot: snapshot,
+	}
+}
+
+// LoadSnapshotRepository creates a new SnapshotRepository from the configured
+// snapshot file, or from the embedded default snapshot when no path is set.
+func LoadSnapshotRepository(cfg *config.CatalogConfig) (*SnapshotRepository, error) {
+	data := defaultSnapshot
+	if cfg.SnapshotPath != "" {
+		var err error
+		data, err = os.ReadFile(cfg.SnapshotPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog snapshot: %w", err)
+		}
+	}
+
+	snapshot, err := DecodeSnapshot(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSnapshotRepository(snapshot), nil
+}
+
+// ListInstances returns all available instances for a given zone.
+func (r *SnapshotRepository) ListInstances(ctx context.Context, zoneID string) ([]*entity.Instance, error) {
+	instances, ok := r.snapshot.Instances[zoneID]
+	if !ok {
+		return nil, fmt.Errorf("zone %s not found in catalog snapshot: %w", zoneID, repository.ErrNotInCatalog)
+	}
+
+	return instances, nil
+}
+
+// GetInstanceByType returns an instance of a given zone by its type.
+func (r *SnapshotRepository) GetInstanceByType(ctx context.Context, zoneID, instanceType string) (*entity.Instance, error) {
+	instances, err := r.ListInstances(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findInstanceByType(instances, zoneID, instanceType)
+}
+
+// ListAddonProviders returns all released addon providers.
+func (r *SnapshotRepository) ListAddonProviders(ctx context.Context) ([]*entity.AddonProvider, error) {
+	return r.snapshot.AddonProviders, nil
+}
+
+// ListZones returns all deployment zones.
+func (r *SnapshotRepository) ListZones(ctx context.Context) ([]*entity.Zone, error) {
+	return r.snapshot.Zones, nil
+}
+
+// DecodeSnapshot reads a catalog snapshot in the JSON snapshot format.
+func DecodeSnapshot(r io.Reader) (*entity.CatalogSnapshot, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode catalog snapshot: %w", err)
+	}
+
+	if file.Version != snapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported catalog snapshot version %d", file.Version)
+	}
+
+	snapshot := entity.NewCatalogSnapshot(file.GeneratedAt)
+	for _, z := range file.Zones {
+		snapshot.Zones = append(snapshot.Zones, zoneToEntity(z))
+	}
+	for zoneID, products := range file.Instances {
+		instances := make([]*entity.Instance, 0, len(products))
+		for _, p := range products {
+			instances = append(instances, productToEntity(p, nil))
+		}
+		snapshot.Instances[zoneID] = instances
+	}
+	for _, p := range file.AddonProviders {
+		snapshot.AddonProviders = append(snapshot.AddonProviders, addonProviderToEntity(p))
+	}
+
+	return snapshot, nil
+}
+
+// EncodeSnapshot writes a catalog snapshot in the JSON snapshot format.
+func EncodeSnapshot(w io.Writer, snapshot *entity.CatalogSnapshot) error {
+	file := snapshotFile{
+		Version:        snapshotFormatVersion,
+		GeneratedAt:    snapshot.GeneratedAt.UTC(),
+		Zones:          make([]APIZone, 0, len(snapshot.Zones)),
+		Instances:      make(map[string][]APIProduct, len(snapshot.Instances)),
+		AddonProviders: make([]APIAddonProvider, 0, len(snapshot.AddonProviders)),
+	}
+
+	for _, z := range snapshot.Zones {
+		file.Zones = append(file.Zones, zoneToAPI(z))
+	}
+	for zoneID, instances := range snapshot.Instances {
+		products := make([]APIProduct, 0, len(instances))
+		for _, inst := range instances {
+			products = append(products, instanceToAPI(inst))
+		}
+		file.Instances[zoneID] = products
+	}
+	for _, p := range snapshot.AddonProviders {
+		file.AddonProviders = append(file.AddonProviders, addonProviderToAPI(p))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return fmt.Errorf("failed to encode catalog snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func instanceToAPI(inst *entity.Instance) APIProduct {
+	flavors := make([]APIFlavor, 0, len(inst.Flavors))
+	for _, f := range inst.Flavors {
+		available := f.Available
+		flavors = append(flavors, APIFlavor{
+			Name:      f.Name,
+			Mem:       f.Mem,
+			CPUs:      f.CPUs,
+			Price:     f.PricePerHour.Float64(),
+			PriceID:   f.PriceID,
+			Available: &available,
+		})
+	}
+
+	return APIProduct{
+		Type:         inst.Type,
+		Name:         inst.Name,
+		Version:      inst.Version,
+		MaxInstances: inst.MaxInstances,
+		Flavors:      flavors,
+	}
+}
+
+func addonProviderToAPI(p *entity.AddonProvider) APIAddonProvider {
+	plans := make([]APIAddonPlan, 0, len(p.Plans))
+	for _, pl := range p.Plans {
+		plans = append(plans, APIAddonPlan{
+			ID:       pl.ID,
+			Name:     pl.Name,
+			Slug:     pl.Slug,
+			Price:    pl.Price.Float64(),
+			PriceID:  pl.PriceID,
+			Features: addonFeaturesToAPI(pl.Features),
+			Zones:    pl.Zones,
+		})
+	}
+
+	return APIAddonProvider{
+		ID:        p.ID,
+		Name:      p.Name,
+		ShortDesc: p.ShortDesc,
+		LogoURL:   p.LogoURL,
+		Status:    p.Status,
+		Regions:   p.Regions,
+		Plans:     plans,
+		Features:  addonFeaturesToAPI(p.Features),
+	}
+}
+
+func addonFeaturesToAPI(features []*entity.AddonFeature) []APIAddonFeature {
+	result := make([]APIAddonFeature, 0, len(features))
+	for _, f := range features {
+		result = append(result, APIAddonFeature{
+			Name:     f.Name,
+			Type:     f.Type,
+			Value:    f.Value,
+			NameCode: f.NameCode,
+		})
+	}
+	return result
+}
+
+func zoneToAPI(z *entity.Zone) APIZone {
+	return APIZone{
+		Name:        z.Name,
+		DisplayName: z.DisplayName,
+		Country:     z.Country,
+		CountryCode: z.CountryCode,
+		City:        z.City,
+		Lat:         z.Lat,
+		Lon:         z.Lon,
+		Tags:        z.Tags,
+	}
+}
@@ -0,0 +1,93 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// UsagePricingRegistry implements UsagePricingRepository from a static registry,
+// as the Clever Cloud API does not expose usage-based prices.
+type UsagePricingRegistry struct {
+	pricing []*entity.UsageBasedPricing
+}
+
+// Ensure UsagePricingRegistry implements UsagePricingRepository.
+var _ repository.UsagePricingRepository = (*UsagePricingRegistry)(nil)
+
+// NewUsagePricingRegistry creates a new UsagePricingRegistry with the default usage-based prices.
+func NewUsagePricingRegistry() *UsagePricingRegistry {
+	return &UsagePricingRegistry{
+		pricing: defaultUsagePricing(),
+	}
+}
+
+// ListUsagePricing returns the usage-based pricing of every addon provider billed by usage.
+func (r *UsagePricingRegistry) ListUsagePricing(ctx context.Context) ([]*entity.UsageBasedPricing, error) {
+	return r.pricing, nil
+}
+
+// GetUsagePricing returns the usage-based pricing of an addon provider, or
+// nil if the provider is not billed by usage.
+func (r *UsagePricingRegistry) GetUsagePricing(ctx context.Context, providerID string) (*entity.UsageBasedPricing, error) {
+	for _, p := range r.pricing {
+		if p.ProviderID == providerID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// defaultUsagePricing returns the usage-based prices published on
+// https://www.clever-cloud.com/pricing/, kept in sync with the frontend registry.
+func defaultUsagePricing() []*entity.UsageBasedPricing {
+	flat := func(pricePerUnit float64) []*entity.PricingTier {
+		return []*entity.PricingTier{{MinThreshold: 0, PricePerUnit: entity.EUR(pricePerUnit)}}
+	}
+
+	return []*entity.UsageBasedPricing{
+		{
+			ProviderID:  "cellar-addon",
+			Description: "Billed by storage and outbound bandwidth",
+			Metrics: []*entity.UsageMetric{
+				{ID: "storage_gb", Name: "Storage", Unit: "GB", Tiers: flat(0.02), DefaultValue: 100, MaxValue: 10000, Step: 10},
+				{ID: "bandwidth_gb", Name: "Outbound bandwidth", Unit: "GB", Tiers: flat(0.09), DefaultValue: 50, MaxValue: 5000, Step: 10},
+			},
+		},
+		{
+			ProviderID:  "fs-bucket",
+			Description: "100 MB free, then billed by storage",
+			Metrics: []*entity.UsageMetric{
+				{ID: "storage_gb", Name: "Storage", Unit: "GB", FreeQuota: 0.1, Tiers: flat(1.65), DefaultValue: 5, MaxValue: 1000, Step: 1},
+			},
+		},
+		{
+			ProviderID:  "addon-pulsar",
+			Description: "Billed by storage and I/O",
+			Metrics: []*entity.UsageMetric{
+				{ID: "storage_gb", Name: "Storage", Unit: "GB", Tiers: []*entity.PricingTier{
+					{MinThreshold: 0, MaxThreshold: 100, PricePerUnit: entity.EUR(0.1)},
+					{MinThreshold: 100, MaxThreshold: 1000, PricePerUnit: entity.EUR(0.08)},
+					{MinThreshold: 1000, PricePerUnit: entity.EUR(0.05)},
+				}, DefaultValue: 50, MaxValue: 5000, Step: 10},
+				{ID: "io_operations", Name: "I/O operations", Unit: "M ops", FreeQuota: 1, Tiers: flat(0.5), DefaultValue: 10, MaxValue: 1000, Step: 1},
+			},
+		},
+		{
+			ProviderID:  "heptapod",
+			Description: "Billed by storage and per user",
+			Metrics: []*entity.UsageMetric{
+				{ID: "storage_gb", Name: "Git storage", Unit: "GB", Tiers: flat(0.02), DefaultValue: 10, MaxValue: 500, Step: 1},
+				{ID: "users", Name: "Users", Unit: "users", Tiers: flat(7), DefaultValue: 5, MinValue: 1, MaxValue: 100, Step: 1},
+			},
+		},
+		{
+			ProviderID:  "kv",
+			Description: "Free (beta)",
+			Metrics: []*entity.UsageMetric{
+				{ID: "storage_gb", Name: "Storage", Unit: "GB", FreeQuota: 1000, Tiers: flat(0), DefaultValue: 1, MaxValue: 100, Step: 1},
+			},
+		},
+	}
+}
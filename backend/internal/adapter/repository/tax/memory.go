@@ -0,0 +1,49 @@
+package tax
+
+import (
+	"context"
+	"sync"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// MemoryRepository implements TaxProfileRepository with in-memory storage.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	profiles map[string]*entity.TaxProfile
+}
+
+// Ensure MemoryRepository implements TaxProfileRepository.
+var _ repository.TaxProfileRepository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		profiles: make(map[string]*entity.TaxProfile),
+	}
+}
+
+// SaveTaxProfile stores the tax profile of an organization, replacing the previous one.
+func (r *MemoryRepository) SaveTaxProfile(ctx context.Context, profile *entity.TaxProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copy := *profile
+	r.profiles[profile.OrganizationID] = &copy
+	return nil
+}
+
+// FindTaxProfile retrieves the tax profile of an organization, or nil if it has none.
+func (r *MemoryRepository) FindTaxProfile(ctx context.Context, organizationID string) (*entity.TaxProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, exists := r.profiles[organizationID]
+	if !exists {
+		return nil, nil
+	}
+
+	copy := *profile
+	return &copy, nil
+}
@@ -0,0 +1,71 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// VATRateTable implements VATRateRepository from a static table of the
+// standard VAT rates of the EU member states.
+type VATRateTable struct {
+	rates []*entity.VATRate
+}
+
+// Ensure VATRateTable implements VATRateRepository.
+var _ repository.VATRateRepository = (*VATRateTable)(nil)
+
+// NewVATRateTable creates a new VATRateTable with the current standard rates.
+func NewVATRateTable() *VATRateTable {
+	return &VATRateTable{
+		rates: defaultVATRates(),
+	}
+}
+
+// ListVATRates returns the standard VAT rates of the EU member states.
+func (t *VATRateTable) ListVATRates(ctx context.Context) ([]*entity.VATRate, error) {
+	return t.rates, nil
+}
+
+// GetVATRate returns the standard VAT rate of a country, or nil outside the EU.
+func (t *VATRateTable) GetVATRate(ctx context.Context, country string) (*entity.VATRate, error) {
+	for _, r := range t.rates {
+		if r.Country == country {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func defaultVATRates() []*entity.VATRate {
+	return []*entity.VATRate{
+		{Country: "AT", Rate: 0.20},
+		{Country: "BE", Rate: 0.21},
+		{Country: "BG", Rate: 0.20},
+		{Country: "CY", Rate: 0.19},
+		{Country: "CZ", Rate: 0.21},
+		{Country: "DE", Rate: 0.19},
+		{Country: "DK", Rate: 0.25},
+		{Country: "EE", Rate: 0.24},
+		{Country: "ES", Rate: 0.21},
+		{Country: "FI", Rate: 0.255},
+		{Country: "FR", Rate: 0.20},
+		{Country: "GR", Rate: 0.24},
+		{Country: "HR", Rate: 0.25},
+		{Country: "HU", Rate: 0.27},
+		{Country: "IE", Rate: 0.23},
+		{Country: "IT", Rate: 0.22},
+		{Country: "LT", Rate: 0.21},
+		{Country: "LU", Rate: 0.17},
+		{Country: "LV", Rate: 0.21},
+		{Country: "MT", Rate: 0.18},
+		{Country: "NL", Rate: 0.21},
+		{Country: "PL", Rate: 0.23},
+		{Country: "PT", Rate: 0.23},
+		{Country: "RO", Rate: 0.21},
+		{Country: "SE", Rate: 0.25},
+		{Country: "SI", Rate: 0.22},
+		{Country: "SK", Rate: 0.23},
+	}
+}
@@ -2,96 +2,320 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
 )
 
-const hoursPerMonth = 730
-
-// RuntimeSpec represents the specification for a runtime cost calculation.
-type RuntimeSpec struct {
-	InstanceType string
-	FlavorName   string
-	MinInstances int32
-	MaxInstances int32
-}
-
-// AddonSpec represents the specification for an addon cost calculation.
-type AddonSpec struct {
-	ProviderID string
-	PlanID     string
-}
+// ErrInvalidUsageEstimate is returned when an addon usage estimate does not
+// match the usage-based pricing of its provider.
+var ErrInvalidUsageEstimate = errors.New("invalid usage estimate")
 
 // CalculateCostCommand represents a command to calculate costs for a project.
 type CalculateCostCommand struct {
-	ProjectID    string
-	RuntimeSpecs []*RuntimeSpec
-	AddonSpecs   []*AddonSpec
+	ProjectID      string
+	OrganizationID string
+	// ZoneID is the zone the project is deployed in, defaults to entity.DefaultZoneID.
+	ZoneID       string
+	RuntimeSpecs []*entity.RuntimeSpec
+	AddonSpecs   []*entity.AddonSpec
+	// BillingCalendar defaults to the configured calendar.
+	BillingCalendar *entity.BillingCalendar
+	// Explain requests the trace of how each cost is derived.
+	Explain bool
+}
+
+// Validate validates the command on its own, the runtimes are checked against
+// the catalog by the handler.
+func (c *CalculateCostCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RuntimeSpecs),
+		validation.Field(&c.AddonSpecs),
+	)
 }
 
 // CalculateCostHandler handles CalculateCostCommand.
 type CalculateCostHandler struct {
-	pricingRepo repository.PricingRepository
+	pricingRepo      repository.PricingRepository
+	addonRepo        repository.AddonCatalogRepository
+	usagePricingRepo repository.UsagePricingRepository
+	scalingSimulator *service.ScalingSimulator
+	defaultCalendar  *entity.BillingCalendar
+	taxProfileRepo   repository.TaxProfileRepository
+	vatRateRepo      repository.VATRateRepository
+	taxCalculator    *service.TaxCalculator
+	agreementRepo    repository.PricingAgreementRepository
 }
 
 // NewCalculateCostHandler creates a new CalculateCostHandler.
-func NewCalculateCostHandler(pricingRepo repository.PricingRepository) *CalculateCostHandler {
+func NewCalculateCostHandler(
+	pricingRepo repository.PricingRepository,
+	addonRepo repository.AddonCatalogRepository,
+	usagePricingRepo repository.UsagePricingRepository,
+	scalingSimulator *service.ScalingSimulator,
+	defaultCalendar *entity.BillingCalendar,
+	taxProfileRepo repository.TaxProfileRepository,
+	vatRateRepo repository.VATRateRepository,
+	taxCalculator *service.TaxCalculator,
+	agreementRepo repository.PricingAgreementRepository,
+) *CalculateCostHandler {
 	return &CalculateCostHandler{
-		pricingRepo: pricingRepo,
+		pricingRepo:      pricingRepo,
+		addonRepo:        addonRepo,
+		usagePricingRepo: usagePricingRepo,
+		scalingSimulator: scalingSimulator,
+		defaultCalendar:  defaultCalendar,
+		taxProfileRepo:   taxProfileRepo,
+		vatRateRepo:      vatRateRepo,
+		taxCalculator:    taxCalculator,
+		agreementRepo:    agreementRepo,
 	}
 }
 
 // Handle executes the CalculateCostCommand and returns a CostEstimation.
 func (h *CalculateCostHandler) Handle(ctx context.Context, cmd *CalculateCostCommand) (*entity.CostEstimation, error) {
-	estimation := entity.NewCostEstimation(cmd.ProjectID)
+	session, err := h.startSession(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return session.Estimation(ctx)
+}
 
-	// Calculate runtime costs
-	for _, spec := range cmd.RuntimeSpecs {
-		runtimeCost, err := h.calculateRuntimeCost(ctx, spec)
-		if err != nil {
-			return nil, fmt.Errorf("failed to calculate runtime cost for %s: %w", spec.InstanceType, err)
+// pricingAgreement returns the pricing agreement of an organization, nil
+// without organization or agreement.
+func (h *CalculateCostHandler) pricingAgreement(ctx context.Context, organizationID string) (*entity.PricingAgreement, error) {
+	if organizationID == "" {
+		return nil, nil
+	}
+	return h.agreementRepo.FindPricingAgreement(ctx, organizationID)
+}
+
+// applyTax taxes an estimation with the tax profile of its organization, if any.
+func (h *CalculateCostHandler) applyTax(ctx context.Context, estimation *entity.CostEstimation) error {
+	if estimation.OrganizationID == "" {
+		return nil
+	}
+
+	profile, err := h.taxProfileRepo.FindTaxProfile(ctx, estimation.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return nil
+	}
+
+	countryRate, err := h.vatRateRepo.GetVATRate(ctx, profile.Country)
+	if err != nil {
+		return err
+	}
+	sellerRate, err := h.vatRateRepo.GetVATRate(ctx, entity.SellerCountry)
+	if err != nil {
+		return err
+	}
+	if sellerRate == nil {
+		return fmt.Errorf("no VAT rate for seller country %s", entity.SellerCountry)
+	}
+
+	return estimation.ApplyTax(h.taxCalculator.Assess(profile, countryRate, sellerRate))
+}
+
+// calculateRuntimeCost prices a runtime with the catalog of the request, or
+// with the pricing agreement when not nil. With explain, the cost carries its
+// trace.
+func (h *CalculateCostHandler) calculateRuntimeCost(ctx context.Context, catalog *pricingCatalog, zoneID string, spec *entity.RuntimeSpec, calendar *entity.BillingCalendar, agreement *entity.PricingAgreement, explain bool) (*entity.RuntimeCost, error) {
+	normalized, listInstance, err := catalog.runtime(ctx, zoneID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := listInstance
+	if agreement != nil {
+		instance = agreement.ApplyFlavorPriceOverrides(listInstance)
+	}
+	var trace *entity.RuntimeCostTrace
+	if explain {
+		trace = &entity.RuntimeCostTrace{}
+	}
+	breakdown := h.estimateRuntimeCost(normalized, instance, calendar, trace)
+	if trace != nil {
+		trace.UnitPrices = unitPriceTraces(normalized, trace.LoadLevels, instance, listInstance)
+	}
+
+	runtimeCost := entity.NewRuntimeCost(
+		fmt.Sprintf("%s-%s", spec.InstanceType, breakdown.baseFlavorName),
+		fmt.Sprintf("%s (%s)", spec.InstanceType, breakdown.baseFlavorName),
+		breakdown.minCost,
+		breakdown.maxCost,
+	)
+	runtimeCost.BaseCost = breakdown.baseCost
+	runtimeCost.ScalingCost = breakdown.scalingCost
+	runtimeCost.EstimatedCost = breakdown.estimatedCost.RoundToCents()
+	runtimeCost.ScalingHours = breakdown.scalingHours
+	runtimeCost.AverageLoadLevel = breakdown.averageLoadLevel
+	runtimeCost.ScalingHoursByProfile = breakdown.scalingHoursByProfile
+	runtimeCost.Spec = normalized
+	runtimeCost.UnitPrice = breakdown.baseHourlyPrice
+	runtimeCost.ListCost = runtimeCost.EstimatedCost
+
+	if agreement != nil {
+		if instance != listInstance {
+			runtimeCost.ListCost = h.estimateRuntimeCost(normalized, listInstance, calendar, nil).estimatedCost.RoundToCents()
+			if trace != nil {
+				trace.AddStep("list_cost", "Estimated cost at catalog prices, before the flavor price overrides", "", runtimeCost.ListCost)
+			}
+		}
+		before := *runtimeCost
+		if err := agreement.DiscountRuntimeCost(runtimeCost); err != nil {
+			return nil, fmt.Errorf("failed to discount runtime cost of %s: %w", spec.InstanceType, err)
+		}
+		if trace != nil {
+			traceDiscount(&trace.TraceSteps, agreement, "unit_price", before.UnitPrice, runtimeCost.UnitPrice)
+			traceDiscount(&trace.TraceSteps, agreement, "min_cost", before.MinCost, runtimeCost.MinCost)
+			traceDiscount(&trace.TraceSteps, agreement, "base_cost", before.BaseCost, runtimeCost.BaseCost)
+			traceDiscount(&trace.TraceSteps, agreement, "estimated_cost", before.EstimatedCost, runtimeCost.EstimatedCost)
+			traceDiscount(&trace.TraceSteps, agreement, "max_cost", before.MaxCost, runtimeCost.MaxCost)
+			trace.AddStep("scaling_cost", "Discounted estimated cost above the discounted base cost, at least 0",
+				fmt.Sprintf("%s − %s", runtimeCost.EstimatedCost, runtimeCost.BaseCost), runtimeCost.ScalingCost)
 		}
-		estimation.AddRuntimeCost(runtimeCost)
 	}
 
-	// Calculate addon costs
-	for _, spec := range cmd.AddonSpecs {
-		addonCost, err := h.calculateAddonCost(ctx, spec)
-		if err != nil {
-			return nil, fmt.Errorf("failed to calculate addon cost for %s: %w", spec.ProviderID, err)
+	if trace != nil {
+		trace.RuntimeID = runtimeCost.RuntimeID
+		runtimeCost.Trace = trace
+	}
+	return runtimeCost, nil
+}
+
+// calculateAddonCost prices an addon with the catalog of the request,
+// discounted by the pricing agreement when not nil. With explain, the cost
+// carries its trace.
+func (h *CalculateCostHandler) calculateAddonCost(ctx context.Context, catalog *pricingCatalog, zoneID string, spec *entity.AddonSpec, agreement *entity.PricingAgreement, explain bool) (*entity.AddonCost, error) {
+	provider, plan, err := catalog.addonPlan(ctx, zoneID, spec.ProviderID, spec.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	usageCosts, err := h.calculateUsageCosts(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	usageCost := entity.ZeroMoney(entity.DefaultCurrency)
+	for _, c := range usageCosts {
+		if usageCost, err = usageCost.CheckedAdd(c.Cost); err != nil {
+			return nil, fmt.Errorf("failed to total usage costs of %s: %w", spec.ProviderID, err)
 		}
-		estimation.AddAddonCost(addonCost)
+	}
+	cost, err := plan.Price.CheckedAdd(usageCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total cost of %s: %w", spec.ProviderID, err)
+	}
+
+	addonCost := entity.NewAddonCost(
+		fmt.Sprintf("%s-%s", spec.ProviderID, spec.PlanID),
+		fmt.Sprintf("%s (%s)", provider.Name, plan.Name),
+		cost,
+	)
+	addonCost.Spec = spec.Clone()
+	addonCost.Spec.ZoneID = zoneID
+	addonCost.UnitPrice = plan.Price
+	addonCost.UsageCost = usageCost
+	addonCost.UsageCosts = usageCosts
+	addonCost.ListCost = addonCost.Cost
+
+	var trace *entity.AddonCostTrace
+	if explain {
+		trace = traceAddonCost(addonCost)
 	}
 
-	return estimation, nil
+	if agreement != nil {
+		before := *addonCost
+		if err := agreement.DiscountAddonCost(addonCost); err != nil {
+			return nil, fmt.Errorf("failed to discount addon cost of %s: %w", spec.ProviderID, err)
+		}
+		if trace != nil {
+			traceDiscount(&trace.TraceSteps, agreement, "unit_price", before.UnitPrice, addonCost.UnitPrice)
+			traceDiscount(&trace.TraceSteps, agreement, "usage_cost", before.UsageCost, addonCost.UsageCost)
+			if agreement.DiscountPercent != 0 {
+				trace.AddStep("cost", "Discounted plan price and usage cost",
+					fmt.Sprintf("%s + %s", addonCost.UnitPrice, addonCost.UsageCost), addonCost.Cost)
+			}
+		}
+	}
+
+	addonCost.Trace = trace
+	return addonCost, nil
 }
 
-func (h *CalculateCostHandler) calculateRuntimeCost(ctx context.Context, spec *RuntimeSpec) (*entity.RuntimeCost, error) {
-	hourlyPrice, err := h.pricingRepo.GetFlavorPrice(ctx, spec.InstanceType, spec.FlavorName)
+// calculateUsageCosts prices the usage of a usage-billed addon, each metric at
+// its estimated value or its default value when not estimated.
+func (h *CalculateCostHandler) calculateUsageCosts(ctx context.Context, spec *entity.AddonSpec) ([]*entity.UsageMetricCost, error) {
+	pricing, err := h.usagePricingRepo.GetUsagePricing(ctx, spec.ProviderID)
 	if err != nil {
 		return nil, err
 	}
+	if pricing == nil {
+		if len(spec.UsageEstimates) > 0 {
+			return nil, fmt.Errorf("addon provider %s is not billed by usage: %w", spec.ProviderID, ErrInvalidUsageEstimate)
+		}
+		return nil, nil
+	}
 
-	monthlyPrice := hourlyPrice * hoursPerMonth
-	minCost := monthlyPrice * float64(spec.MinInstances)
-	maxCost := monthlyPrice * float64(spec.MaxInstances)
+	values := make(map[string]float64, len(spec.UsageEstimates))
+	for _, e := range spec.UsageEstimates {
+		metric := pricing.FindMetric(e.MetricID)
+		if metric == nil {
+			return nil, fmt.Errorf("unknown usage metric %s for addon provider %s: %w", e.MetricID, spec.ProviderID, ErrInvalidUsageEstimate)
+		}
+		if math.IsNaN(e.Value) || math.IsInf(e.Value, 0) {
+			return nil, fmt.Errorf("usage metric %s must be a finite number: %w", e.MetricID, ErrInvalidUsageEstimate)
+		}
+		if e.Value < metric.MinValue || (metric.MaxValue > 0 && e.Value > metric.MaxValue) {
+			return nil, fmt.Errorf("usage metric %s must be between %v and %v %s: %w", e.MetricID, metric.MinValue, metric.MaxValue, metric.Unit, ErrInvalidUsageEstimate)
+		}
+		values[e.MetricID] = e.Value
+	}
 
-	return entity.NewRuntimeCost(
-		fmt.Sprintf("%s-%s", spec.InstanceType, spec.FlavorName),
-		fmt.Sprintf("%s (%s)", spec.InstanceType, spec.FlavorName),
-		minCost,
-		maxCost,
-	), nil
+	costs := make([]*entity.UsageMetricCost, 0, len(pricing.Metrics))
+	for _, m := range pricing.Metrics {
+		value, ok := values[m.ID]
+		if !ok {
+			value = m.DefaultValue
+		}
+		cost, err := m.CostOf(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price usage metric %s: %w", m.ID, err)
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
 }
 
-func (h *CalculateCostHandler) calculateAddonCost(ctx context.Context, spec *AddonSpec) (*entity.AddonCost, error) {
-	// For now, return a placeholder cost
-	// In a real implementation, this would fetch the addon price from an API
-	return entity.NewAddonCost(
-		fmt.Sprintf("%s-%s", spec.ProviderID, spec.PlanID),
-		fmt.Sprintf("%s (%s)", spec.ProviderID, spec.PlanID),
-		0, // Placeholder cost
-	), nil
+// billingCalendar completes a requested billing calendar with the configured one.
+func (h *CalculateCostHandler) billingCalendar(requested *entity.BillingCalendar) *entity.BillingCalendar {
+	if requested == nil {
+		return h.defaultCalendar
+	}
+
+	calendar := *requested
+	if calendar.Kind == "" {
+		calendar.Kind = h.defaultCalendar.Kind
+	}
+	if calendar.Location == nil {
+		calendar.Location = h.defaultCalendar.Location
+	}
+	return &calendar
+}
+
+// resolveZone returns the spec zone when set, the command zone otherwise.
+func resolveZone(specZoneID, defaultZoneID string) string {
+	if specZoneID != "" {
+		return specZoneID
+	}
+	return defaultZoneID
 }
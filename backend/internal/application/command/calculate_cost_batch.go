@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// CalculateCostBatchCommand represents a command to calculate the costs of
+// several projects of an organization at once.
+type CalculateCostBatchCommand struct {
+	// OrganizationID applies to the items without one. Items of another
+	// organization fail.
+	OrganizationID string
+	Items          []*CalculateCostBatchItem
+	// MaxWorkers is the most items computed at once, defaults to and is
+	// capped by the configured limit.
+	MaxWorkers int
+}
+
+// CalculateCostBatchItem is an item of a batch: the command of a project, or
+// the error met building it from the request, which fails the item.
+type CalculateCostBatchItem struct {
+	Command *CalculateCostCommand
+	Err     error
+}
+
+// Validate validates the batch itself. Items are validated when they are
+// computed, an invalid item only fails on its own.
+func (c *CalculateCostBatchCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Items, validation.Required, validation.Skip),
+		validation.Field(&c.MaxWorkers, validation.Min(0)),
+	)
+}
+
+// CalculateCostBatchHandler handles CalculateCostBatchCommand.
+type CalculateCostBatchHandler struct {
+	calculateCostHandler *CalculateCostHandler
+	maxWorkers           int
+	maxItems             int
+}
+
+// NewCalculateCostBatchHandler creates a new CalculateCostBatchHandler
+// computing at most maxWorkers items at once, of batches of at most maxItems.
+func NewCalculateCostBatchHandler(calculateCostHandler *CalculateCostHandler, maxWorkers, maxItems int) *CalculateCostBatchHandler {
+	return &CalculateCostBatchHandler{
+		calculateCostHandler: calculateCostHandler,
+		maxWorkers:           maxWorkers,
+		maxItems:             maxItems,
+	}
+}
+
+// Handle executes the CalculateCostBatchCommand. Each item is calculated like
+// CalculateCost, in parallel, and an item error is reported with the item
+// instead of failing the batch. It fails with the context error when the
+// context is done before every item is calculated.
+func (h *CalculateCostBatchHandler) Handle(ctx context.Context, cmd *CalculateCostBatchCommand) (*entity.CostEstimationBatch, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+	if err := (validation.Errors{
+		"Items": validation.Validate(cmd.Items, validation.Length(0, h.maxItems), validation.Skip),
+	}).Filter(); err != nil {
+		return nil, err
+	}
+
+	workers := h.maxWorkers
+	if cmd.MaxWorkers > 0 {
+		workers = min(workers, cmd.MaxWorkers)
+	}
+	workers = min(workers, len(cmd.Items))
+
+	items := make([]*entity.CostEstimationBatchItem, len(cmd.Items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item := cmd.Items[i]
+				if item.Err != nil {
+					items[i] = &entity.CostEstimationBatchItem{Err: item.Err}
+					continue
+				}
+				estimation, err := h.recoverItem(ctx, cmd, item.Command)
+				items[i] = &entity.CostEstimationBatchItem{Estimation: estimation, Err: err}
+			}
+		}()
+	}
+
+feed:
+	for i := range cmd.Items {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return entity.NewCostEstimationBatch(items), nil
+}
+
+// recoverItem calculates an item of the batch like calculateItem, reporting a
+// panic as the item error: the workers run outside of the request goroutine,
+// where a panic would stop the server.
+func (h *CalculateCostBatchHandler) recoverItem(ctx context.Context, batch *CalculateCostBatchCommand, item *CalculateCostCommand) (estimation *entity.CostEstimation, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			estimation, err = nil, fmt.Errorf("failed to calculate batch item: panic: %v", r)
+		}
+	}()
+	return h.calculateItem(ctx, batch, item)
+}
+
+// calculateItem calculates an item of the batch, in the organization of the
+// batch unless it has its own.
+func (h *CalculateCostBatchHandler) calculateItem(ctx context.Context, batch *CalculateCostBatchCommand, item *CalculateCostCommand) (*entity.CostEstimation, error) {
+	itemCmd := *item
+	switch {
+	case itemCmd.OrganizationID == "":
+		itemCmd.OrganizationID = batch.OrganizationID
+	case batch.OrganizationID != "" && itemCmd.OrganizationID != batch.OrganizationID:
+		return nil, validation.Errors{
+			"OrganizationID": validation.NewError("validation_organization_mismatch", "must be empty or the organization of the batch"),
+		}
+	}
+
+	return h.calculateCostHandler.Handle(ctx, &itemCmd)
+}
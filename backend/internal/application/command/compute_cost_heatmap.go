@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// ComputeCostHeatmapCommand represents a command to compute the hourly cost of
+// a project over the hours of its weekly schedules.
+type ComputeCostHeatmapCommand struct {
+	ProjectID    string
+	ZoneID       string
+	RuntimeSpecs []*entity.RuntimeSpec
+}
+
+// Validate validates the command on its own, the runtimes are checked against
+// the catalog by the handler.
+func (c *ComputeCostHeatmapCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RuntimeSpecs),
+	)
+}
+
+// ComputeCostHeatmapHandler handles ComputeCostHeatmapCommand.
+type ComputeCostHeatmapHandler struct {
+	calculateCostHandler *CalculateCostHandler
+}
+
+// NewComputeCostHeatmapHandler creates a new ComputeCostHeatmapHandler.
+func NewComputeCostHeatmapHandler(calculateCostHandler *CalculateCostHandler) *ComputeCostHeatmapHandler {
+	return &ComputeCostHeatmapHandler{
+		calculateCostHandler: calculateCostHandler,
+	}
+}
+
+// Handle executes the ComputeCostHeatmapCommand and returns the heatmap of the project.
+func (h *ComputeCostHeatmapHandler) Handle(ctx context.Context, cmd *ComputeCostHeatmapCommand) (*entity.CostHeatmap, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	zoneID := cmd.ZoneID
+	if zoneID == "" {
+		zoneID = entity.DefaultZoneID
+	}
+	catalog := h.calculateCostHandler.newPricingCatalog()
+	if err := catalog.validate(ctx, zoneID, cmd.RuntimeSpecs, nil); err != nil {
+		return nil, err
+	}
+
+	zero := entity.ZeroMoney(entity.DefaultCurrency)
+	heatmap := &entity.CostHeatmap{
+		Runtimes:   make([]*entity.RuntimeCostHeatmap, 0, len(cmd.RuntimeSpecs)),
+		WeeklyCost: zero,
+	}
+	for day := range heatmap.HourlyCosts {
+		for hour := range heatmap.HourlyCosts[day] {
+			heatmap.HourlyCosts[day][hour] = zero
+		}
+	}
+
+	for _, spec := range cmd.RuntimeSpecs {
+		rt, err := h.runtimeHeatmap(ctx, catalog, resolveZone(spec.ZoneID, zoneID), spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price runtime %s: %w", spec.InstanceType, err)
+		}
+
+		for day := range rt.Hours {
+			for hour, cell := range rt.Hours[day] {
+				heatmap.HourlyCosts[day][hour] = heatmap.HourlyCosts[day][hour].Add(cell.HourlyCost)
+			}
+		}
+		heatmap.WeeklyCost = heatmap.WeeklyCost.Add(rt.WeeklyCost)
+		heatmap.Runtimes = append(heatmap.Runtimes, rt)
+	}
+
+	return heatmap, nil
+}
+
+// runtimeHeatmap prices every hour of a runtime schedule at its load level.
+func (h *ComputeCostHeatmapHandler) runtimeHeatmap(ctx context.Context, catalog *pricingCatalog, zoneID string, spec *entity.RuntimeSpec) (*entity.RuntimeCostHeatmap, error) {
+	normalized, instance, err := catalog.runtime(ctx, zoneID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pricer := h.calculateCostHandler.newRuntimePricer(normalized, instance)
+	rt := &entity.RuntimeCostHeatmap{
+		InstanceType: normalized.InstanceType,
+		WeeklyCost:   entity.ZeroMoney(entity.DefaultCurrency),
+	}
+	for day := range normalized.Schedule {
+		for hour, slot := range normalized.Schedule[day] {
+			profile, state := pricer.slotState(slot)
+			cell := &entity.CostHeatmapCell{
+				LoadLevel:  state.LoadLevel,
+				FlavorName: state.FlavorName,
+				Instances:  state.Instances,
+				HourlyCost: state.HourlyCost,
+			}
+			if profile != nil {
+				cell.ProfileID = profile.ID
+			}
+
+			rt.Hours[day][hour] = cell
+			rt.WeeklyCost = rt.WeeklyCost.Add(cell.HourlyCost)
+		}
+	}
+
+	return rt, nil
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// unitPriceTraces returns the hourly prices of the flavors a runtime runs: its
+// baseline, the flavors of its load levels, then the bounds of its enabled
+// profiles.
+func unitPriceTraces(spec *entity.RuntimeSpec, loadLevels []*entity.LoadLevelTrace, instance, listInstance *entity.Instance) []*entity.UnitPriceTrace {
+	names := []string{spec.Baseline.FlavorName}
+	for _, l := range loadLevels {
+		names = append(names, l.FlavorName)
+	}
+	if spec.ScalingEnabled {
+		for _, profile := range spec.ScalingProfiles {
+			if profile.Enabled {
+				names = append(names, profile.MinFlavorName, profile.MaxFlavorName)
+			}
+		}
+	}
+
+	prices := make([]*entity.UnitPriceTrace, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		flavor, listFlavor := instance.FindFlavorByName(name), listInstance.FindFlavorByName(name)
+		if flavor == nil || listFlavor == nil {
+			continue
+		}
+		prices = append(prices, &entity.UnitPriceTrace{
+			FlavorName:       name,
+			PricePerHour:     flavor.PricePerHour,
+			ListPricePerHour: listFlavor.PricePerHour,
+		})
+	}
+	return prices
+}
+
+// traceAddonCost returns the trace of an addon cost at catalog prices.
+func traceAddonCost(ac *entity.AddonCost) *entity.AddonCostTrace {
+	trace := &entity.AddonCostTrace{
+		AddonID: ac.AddonID,
+		Usage:   ac.UsageCosts,
+	}
+	trace.AddStep("unit_price", "Monthly price of the plan", "", ac.UnitPrice)
+
+	usage := make([]string, 0, len(ac.UsageCosts))
+	for _, c := range ac.UsageCosts {
+		tiers := make([]string, 0, len(c.Tiers))
+		for _, t := range c.Tiers {
+			tiers = append(tiers, fmt.Sprintf("%.9g × %s", t.Quantity, t.Tier.PricePerUnit))
+		}
+		formula := "0"
+		if len(tiers) > 0 {
+			formula = fmt.Sprintf("round(%s)", strings.Join(tiers, " + "))
+		}
+		trace.AddStep("", fmt.Sprintf("Usage cost of %s, %.9g %s of which %.9g free", c.MetricName, c.Value, c.Unit, c.FreeQuotaApplied), formula, c.Cost)
+		usage = append(usage, c.Cost.String())
+	}
+	if len(usage) > 0 {
+		trace.AddStep("usage_cost", "Sum of the usage costs", strings.Join(usage, " + "), ac.UsageCost)
+	}
+
+	trace.AddStep("cost", "Plan price and usage cost, rounded to the cent",
+		fmt.Sprintf("round(%s + %s)", ac.UnitPrice, ac.UsageCost), ac.Cost)
+	return trace
+}
+
+// traceDiscount appends the step discounting a figure with a pricing
+// agreement, shown rounded when rounding changed it.
+func traceDiscount(trace *entity.TraceSteps, agreement *entity.PricingAgreement, figure string, before, after entity.Money) {
+	if agreement.DiscountPercent == 0 {
+		return
+	}
+	formula := fmt.Sprintf("%s × %g", before, 1-agreement.DiscountPercent/100)
+	if discounted, err := agreement.Discount(before); err != nil || after.Cmp(discounted) != 0 {
+		formula = fmt.Sprintf("round(%s)", formula)
+	}
+	trace.AddStep(figure, fmt.Sprintf("Discounted by %g%%", agreement.DiscountPercent), formula, after)
+}
+
+// traceEstimation returns the trace of an estimation from the traces of its
+// lines, deriving its totals.
+func traceEstimation(e *entity.CostEstimation) *entity.CostTrace {
+	trace := &entity.CostTrace{
+		HoursPerMonth: e.BillingCalendar.BilledHoursPerMonth(),
+		Runtimes:      make([]*entity.RuntimeCostTrace, 0, len(e.RuntimeCosts)),
+		Addons:        make([]*entity.AddonCostTrace, 0, len(e.AddonCosts)),
+	}
+	minCosts := make([]string, 0, len(e.RuntimeCosts)+len(e.AddonCosts))
+	maxCosts := make([]string, 0, cap(minCosts))
+	estimatedCosts := make([]string, 0, cap(minCosts))
+	for _, rc := range e.RuntimeCosts {
+		if rc.Trace != nil {
+			trace.Runtimes = append(trace.Runtimes, rc.Trace)
+		}
+		minCosts = append(minCosts, rc.MinCost.String())
+		maxCosts = append(maxCosts, rc.MaxCost.String())
+		estimatedCosts = append(estimatedCosts, rc.EstimatedCost.String())
+	}
+	for _, ac := range e.AddonCosts {
+		if ac.Trace != nil {
+			trace.Addons = append(trace.Addons, ac.Trace)
+		}
+		minCosts = append(minCosts, ac.Cost.String())
+		maxCosts = append(maxCosts, ac.Cost.String())
+		estimatedCosts = append(estimatedCosts, ac.Cost.String())
+	}
+
+	trace.AddStep("min_monthly_cost", "Sum of the min runtime costs and addon costs", sumFormula(minCosts), e.MinMonthlyCost)
+	trace.AddStep("estimated_monthly_cost", "Sum of the estimated runtime costs and addon costs", sumFormula(estimatedCosts), e.EstimatedMonthlyCost)
+	trace.AddStep("max_monthly_cost", "Sum of the max runtime costs and addon costs", sumFormula(maxCosts), e.MaxMonthlyCost)
+
+	if a := e.Agreement; a != nil {
+		trace.AddStep("list_monthly_cost", "Sum of the list costs of the lines, at catalog prices", "", a.ListMonthlyCost)
+		trace.AddStep("discount_amount", "List cost above the estimated cost",
+			fmt.Sprintf("%s − %s", a.ListMonthlyCost, e.EstimatedMonthlyCost), a.DiscountAmount)
+		trace.AddStep("billed_monthly_cost", "Estimated cost, at least the monthly commitment",
+			fmt.Sprintf("max(%s, %s)", e.EstimatedMonthlyCost, a.MonthlyCommitment), a.BilledMonthlyCost)
+	}
+
+	if t := e.Tax; t != nil {
+		rate := fmt.Sprintf("%g%%", t.Assessment.Rate*100)
+		trace.AddStep("tax.estimated.tax", fmt.Sprintf("Sum of the taxes of the lines, each rounded to the cent at %s", rate), "", t.Estimated.Tax)
+		trace.AddStep("tax.estimated.gross", "Estimated cost with taxes",
+			fmt.Sprintf("%s + %s", t.Estimated.Net, t.Estimated.Tax), t.Estimated.Gross)
+	}
+
+	return trace
+}
+
+// sumFormula returns the sum of amounts as a formula.
+func sumFormula(amounts []string) string {
+	if len(amounts) == 0 {
+		return "0"
+	}
+	return strings.Join(amounts, " + ")
+}
@@ -0,0 +1,172 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// pricingCatalog is the catalog a request is priced against. The instances of
+// each zone and the addon providers are fetched on first use and kept for the
+// rest of the request, so that all its lines read the same prices even if the
+// catalog changes meanwhile. It is not safe for concurrent use.
+type pricingCatalog struct {
+	pricingRepo    repository.PricingRepository
+	addonRepo      repository.AddonCatalogRepository
+	zones          map[string]*entity.ZoneCatalog
+	addonProviders []*entity.AddonProvider
+}
+
+// newPricingCatalog creates the catalog of a new request.
+func (h *CalculateCostHandler) newPricingCatalog() *pricingCatalog {
+	return &pricingCatalog{
+		pricingRepo: h.pricingRepo,
+		addonRepo:   h.addonRepo,
+		zones:       make(map[string]*entity.ZoneCatalog),
+	}
+}
+
+// zone returns the instances of a zone, fetched once per request.
+func (c *pricingCatalog) zone(ctx context.Context, zoneID string) (*entity.ZoneCatalog, error) {
+	if zone, ok := c.zones[zoneID]; ok {
+		return zone, nil
+	}
+
+	instances, err := c.pricingRepo.ListInstances(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	zone := entity.NewZoneCatalog(zoneID, instances)
+	c.zones[zoneID] = zone
+	return zone, nil
+}
+
+// instance returns an instance of a zone by its type.
+func (c *pricingCatalog) instance(ctx context.Context, zoneID, instanceType string) (*entity.Instance, error) {
+	zone, err := c.zone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := zone.FindInstance(instanceType)
+	if instance == nil {
+		return nil, fmt.Errorf("instance type %s not found in zone %s: %w", instanceType, zoneID, repository.ErrNotInCatalog)
+	}
+	return instance, nil
+}
+
+// runtime returns a runtime spec normalized in its zone and its instance,
+// after checking that the instance offers every flavor the spec refers to.
+func (c *pricingCatalog) runtime(ctx context.Context, zoneID string, spec *entity.RuntimeSpec) (*entity.RuntimeSpec, *entity.Instance, error) {
+	instance, err := c.instance(ctx, zoneID, spec.InstanceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	normalized := spec.Normalize(zoneID)
+	flavorNames := []string{normalized.Baseline.FlavorName}
+	for _, p := range normalized.ScalingProfiles {
+		flavorNames = append(flavorNames, p.MinFlavorName, p.MaxFlavorName)
+	}
+	for _, name := range flavorNames {
+		if name != "" && c.zones[zoneID].FindFlavor(spec.InstanceType, name) == nil {
+			return nil, nil, fmt.Errorf("flavor %s not found for instance type %s: %w", name, spec.InstanceType, repository.ErrNotInCatalog)
+		}
+	}
+
+	return normalized, instance, nil
+}
+
+// validate checks runtime specs against the instances of their zone and
+// addon specs against the plans of their provider, see
+// entity.RuntimeSpec.ValidateCatalog and entity.AddonSpec.ValidateCatalog.
+// Violations are reported by index under RuntimeSpecs and AddonSpecs, like
+// the errors of the Validate method of commands.
+func (c *pricingCatalog) validate(ctx context.Context, zoneID string, runtimeSpecs []*entity.RuntimeSpec, addonSpecs []*entity.AddonSpec) error {
+	runtimes := validation.Errors{}
+	for i, spec := range runtimeSpecs {
+		if err := collectErrors(runtimes, i, c.validateRuntime(ctx, resolveZone(spec.ZoneID, zoneID), spec)); err != nil {
+			return err
+		}
+	}
+	addons := validation.Errors{}
+	for i, spec := range addonSpecs {
+		if err := collectErrors(addons, i, c.validateAddon(ctx, resolveZone(spec.ZoneID, zoneID), spec)); err != nil {
+			return err
+		}
+	}
+	return validation.Errors{"RuntimeSpecs": runtimes.Filter(), "AddonSpecs": addons.Filter()}.Filter()
+}
+
+// collectErrors adds the validation errors of the spec at an index to errs,
+// and returns other errors.
+func collectErrors(errs validation.Errors, index int, err error) error {
+	var specErrs validation.Errors
+	if err != nil && !errors.As(err, &specErrs) {
+		return err
+	}
+	if err != nil {
+		errs[strconv.Itoa(index)] = specErrs
+	}
+	return nil
+}
+
+// validateRuntime checks a runtime spec against the instances of its zone.
+// Violations are returned as validation.Errors, other errors as they are.
+func (c *pricingCatalog) validateRuntime(ctx context.Context, zoneID string, spec *entity.RuntimeSpec) error {
+	instance, err := c.instance(ctx, zoneID, spec.InstanceType)
+	if errors.Is(err, repository.ErrNotInCatalog) {
+		return validation.Errors{
+			"InstanceType": validation.NewError("validation_instance_not_found", fmt.Sprintf("must be an instance type of zone %s", zoneID)),
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return spec.ValidateCatalog(instance)
+}
+
+// validateAddon checks an addon spec against the plans of its provider.
+// Violations are returned as validation.Errors, other errors as they are.
+func (c *pricingCatalog) validateAddon(ctx context.Context, zoneID string, spec *entity.AddonSpec) error {
+	providers, err := c.providers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range providers {
+		if p.ID == spec.ProviderID {
+			return spec.ValidateCatalog(p, zoneID)
+		}
+	}
+	return validation.Errors{
+		"ProviderID": validation.NewError("validation_addon_provider_not_found", "must be an addon provider of the catalog"),
+	}
+}
+
+// providers returns the addon providers, fetched once per request.
+func (c *pricingCatalog) providers(ctx context.Context) ([]*entity.AddonProvider, error) {
+	if c.addonProviders == nil {
+		providers, err := c.addonRepo.ListAddonProviders(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.addonProviders = providers
+	}
+	return c.addonProviders, nil
+}
+
+// addonPlan returns an addon provider and one of its plans, which must be
+// available in the zone.
+func (c *pricingCatalog) addonPlan(ctx context.Context, zoneID, providerID, planID string) (*entity.AddonProvider, *entity.AddonPlan, error) {
+	providers, err := c.providers(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repository.FindAddonPlan(providers, zoneID, providerID, planID)
+}
@@ -0,0 +1,245 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// RecalculationEdit represents an incremental edit of the project of a
+// RecalculationSession. Validation errors are reported on the fields of the
+// edit.
+type RecalculationEdit interface {
+	Validate() error
+	// apply applies the edit to the session, repricing only the lines it
+	// changes. The session is left unchanged when it fails.
+	apply(ctx context.Context, s *RecalculationSession) error
+}
+
+// AddRuntimeEdit adds a runtime at the end of the project.
+type AddRuntimeEdit struct {
+	Spec *entity.RuntimeSpec
+}
+
+// Validate validates the edit.
+func (e *AddRuntimeEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.Spec, validation.Required),
+	)
+}
+
+func (e *AddRuntimeEdit) apply(ctx context.Context, s *RecalculationSession) error {
+	runtimeCost, err := s.priceRuntime(ctx, e.Spec)
+	if err != nil {
+		return fieldErrors("Spec", err)
+	}
+	s.project.RuntimeSpecs = append(slices.Clip(s.project.RuntimeSpecs), e.Spec)
+	s.runtimeCosts = append(s.runtimeCosts, runtimeCost)
+	return nil
+}
+
+// UpdateRuntimeEdit replaces a runtime of the project.
+type UpdateRuntimeEdit struct {
+	RuntimeIndex int
+	Spec         *entity.RuntimeSpec
+}
+
+// Validate validates the edit.
+func (e *UpdateRuntimeEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.RuntimeIndex, validation.Min(0)),
+		validation.Field(&e.Spec, validation.Required),
+	)
+}
+
+func (e *UpdateRuntimeEdit) apply(ctx context.Context, s *RecalculationSession) error {
+	if err := s.checkRuntimeIndex(e.RuntimeIndex); err != nil {
+		return err
+	}
+	runtimeCost, err := s.priceRuntime(ctx, e.Spec)
+	if err != nil {
+		return fieldErrors("Spec", err)
+	}
+	s.replaceRuntime(e.RuntimeIndex, e.Spec, runtimeCost)
+	return nil
+}
+
+// ChangeFlavorEdit changes the baseline flavor of a runtime, or its flavor
+// when it has no baseline.
+type ChangeFlavorEdit struct {
+	RuntimeIndex int
+	FlavorName   string
+}
+
+// Validate validates the edit.
+func (e *ChangeFlavorEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.RuntimeIndex, validation.Min(0)),
+		validation.Field(&e.FlavorName, validation.Required),
+	)
+}
+
+func (e *ChangeFlavorEdit) apply(ctx context.Context, s *RecalculationSession) error {
+	if err := s.checkRuntimeIndex(e.RuntimeIndex); err != nil {
+		return err
+	}
+
+	spec := s.project.RuntimeSpecs[e.RuntimeIndex].Clone()
+	instance, err := s.catalog.instance(ctx, resolveZone(spec.ZoneID, s.zoneID), spec.InstanceType)
+	if err != nil {
+		return err
+	}
+	if err := instance.ValidateFlavor(e.FlavorName); err != nil {
+		return validation.Errors{"FlavorName": err}
+	}
+
+	if spec.Baseline != nil {
+		spec.Baseline.FlavorName = e.FlavorName
+	} else {
+		spec.FlavorName = e.FlavorName
+	}
+	runtimeCost, err := s.priceRuntime(ctx, spec)
+	if err != nil {
+		return err
+	}
+	s.replaceRuntime(e.RuntimeIndex, spec, runtimeCost)
+	return nil
+}
+
+// PaintScheduleEdit sets hours of the weekly schedule of a runtime.
+type PaintScheduleEdit struct {
+	RuntimeIndex int
+	Cells        []*ScheduleCell
+}
+
+// ScheduleCell represents the scaling configuration of one hour of the week.
+type ScheduleCell struct {
+	// Day is the day of the week, 0 being Monday.
+	Day  int
+	Hour int
+	entity.HourlyConfig
+}
+
+// Validate validates the edit. Profile IDs are checked against the runtime
+// when the edit is applied.
+func (e *PaintScheduleEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.RuntimeIndex, validation.Min(0)),
+		validation.Field(&e.Cells, validation.Required),
+	)
+}
+
+// Validate validates the cell.
+func (c *ScheduleCell) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Day, validation.Min(0), validation.Max(entity.DaysPerWeek-1)),
+		validation.Field(&c.Hour, validation.Min(0), validation.Max(entity.HoursPerDay-1)),
+		validation.Field(&c.LoadLevel, validation.Min(int32(entity.MinLoadLevel)), validation.Max(int32(entity.MaxLoadLevel))),
+	)
+}
+
+func (e *PaintScheduleEdit) apply(ctx context.Context, s *RecalculationSession) error {
+	if err := s.checkRuntimeIndex(e.RuntimeIndex); err != nil {
+		return err
+	}
+
+	spec := s.project.RuntimeSpecs[e.RuntimeIndex].Clone()
+	if spec.Schedule == nil {
+		spec.Schedule = &entity.WeeklySchedule{}
+	}
+	cells := validation.Errors{}
+	for i, c := range e.Cells {
+		if c.ProfileID != "" && !slices.ContainsFunc(spec.ScalingProfiles, func(p *entity.ScalingProfile) bool { return p.ID == c.ProfileID }) {
+			cells[strconv.Itoa(i)] = validation.Errors{
+				"ProfileID": validation.NewError("validation_unknown_profile", "must be the ID of a scaling profile of the runtime"),
+			}
+			continue
+		}
+		spec.Schedule[c.Day][c.Hour] = c.HourlyConfig
+	}
+	if len(cells) > 0 {
+		return validation.Errors{"Cells": cells}
+	}
+
+	runtimeCost, err := s.priceRuntime(ctx, spec)
+	if err != nil {
+		return err
+	}
+	s.replaceRuntime(e.RuntimeIndex, spec, runtimeCost)
+	return nil
+}
+
+// RemoveRuntimeEdit removes a runtime from the project.
+type RemoveRuntimeEdit struct {
+	RuntimeIndex int
+}
+
+// Validate validates the edit.
+func (e *RemoveRuntimeEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.RuntimeIndex, validation.Min(0)),
+	)
+}
+
+func (e *RemoveRuntimeEdit) apply(_ context.Context, s *RecalculationSession) error {
+	if err := s.checkRuntimeIndex(e.RuntimeIndex); err != nil {
+		return err
+	}
+	s.project.RuntimeSpecs = slices.Delete(slices.Clone(s.project.RuntimeSpecs), e.RuntimeIndex, e.RuntimeIndex+1)
+	s.runtimeCosts = slices.Delete(s.runtimeCosts, e.RuntimeIndex, e.RuntimeIndex+1)
+	return nil
+}
+
+// AddAddonEdit adds an addon at the end of the project.
+type AddAddonEdit struct {
+	Spec *entity.AddonSpec
+}
+
+// Validate validates the edit.
+func (e *AddAddonEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.Spec, validation.Required),
+	)
+}
+
+func (e *AddAddonEdit) apply(ctx context.Context, s *RecalculationSession) error {
+	addonCost, err := s.priceAddon(ctx, e.Spec)
+	if err != nil {
+		return fieldErrors("Spec", err)
+	}
+	s.project.AddonSpecs = append(slices.Clip(s.project.AddonSpecs), e.Spec)
+	s.addonCosts = append(s.addonCosts, addonCost)
+	return nil
+}
+
+// RemoveAddonEdit removes an addon from the project.
+type RemoveAddonEdit struct {
+	AddonIndex int
+}
+
+// Validate validates the edit.
+func (e *RemoveAddonEdit) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.AddonIndex, validation.Min(0)),
+	)
+}
+
+func (e *RemoveAddonEdit) apply(_ context.Context, s *RecalculationSession) error {
+	if err := s.checkAddonIndex(e.AddonIndex); err != nil {
+		return err
+	}
+	s.project.AddonSpecs = slices.Delete(slices.Clone(s.project.AddonSpecs), e.AddonIndex, e.AddonIndex+1)
+	s.addonCosts = slices.Delete(s.addonCosts, e.AddonIndex, e.AddonIndex+1)
+	return nil
+}
+
+// replaceRuntime replaces a runtime of the project and its cost.
+func (s *RecalculationSession) replaceRuntime(index int, spec *entity.RuntimeSpec, runtimeCost *entity.RuntimeCost) {
+	s.project.RuntimeSpecs = slices.Clone(s.project.RuntimeSpecs)
+	s.project.RuntimeSpecs[index] = spec
+	s.runtimeCosts[index] = runtimeCost
+}
@@ -0,0 +1,206 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// RecalculationSession holds a project being edited along with the cost of
+// each of its lines, so that an edit only reprices the lines it changes. The
+// catalog, billing calendar and pricing agreement are read once when the
+// session starts. It is not safe for concurrent use.
+type RecalculationSession struct {
+	handler      *CalculateCostHandler
+	catalog      *pricingCatalog
+	project      CalculateCostCommand
+	zoneID       string
+	pricedAt     time.Time
+	calendar     *entity.BillingCalendar
+	agreement    *entity.PricingAgreement
+	runtimeCosts []*entity.RuntimeCost
+	addonCosts   []*entity.AddonCost
+	revision     int
+}
+
+// RecalculationSessionHandler starts recalculation sessions.
+type RecalculationSessionHandler struct {
+	calculateCostHandler *CalculateCostHandler
+}
+
+// NewRecalculationSessionHandler creates a new RecalculationSessionHandler.
+func NewRecalculationSessionHandler(calculateCostHandler *CalculateCostHandler) *RecalculationSessionHandler {
+	return &RecalculationSessionHandler{
+		calculateCostHandler: calculateCostHandler,
+	}
+}
+
+// Handle starts a session on the project of the command, priced like
+// CalculateCost.
+func (h *RecalculationSessionHandler) Handle(ctx context.Context, cmd *CalculateCostCommand) (*RecalculationSession, error) {
+	return h.calculateCostHandler.startSession(ctx, cmd)
+}
+
+// startSession validates the command and prices every line of its project.
+func (h *CalculateCostHandler) startSession(ctx context.Context, cmd *CalculateCostCommand) (*RecalculationSession, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &RecalculationSession{
+		handler:  h,
+		catalog:  h.newPricingCatalog(),
+		project:  *cmd,
+		zoneID:   resolveZone(cmd.ZoneID, entity.DefaultZoneID),
+		pricedAt: time.Now(),
+	}
+	s.calendar = h.billingCalendar(cmd.BillingCalendar).Resolve(s.pricedAt)
+
+	agreement, err := h.pricingAgreement(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	s.agreement = agreement
+
+	// Lines are checked against the catalog as they are priced, violations
+	// are reported by index like those of catalog.validate
+	runtimes := validation.Errors{}
+	s.runtimeCosts = make([]*entity.RuntimeCost, 0, len(cmd.RuntimeSpecs))
+	for i, spec := range cmd.RuntimeSpecs {
+		runtimeCost, err := s.priceRuntime(ctx, spec)
+		if err := collectErrors(runtimes, i, err); err != nil {
+			return nil, err
+		}
+		s.runtimeCosts = append(s.runtimeCosts, runtimeCost)
+	}
+
+	addons := validation.Errors{}
+	s.addonCosts = make([]*entity.AddonCost, 0, len(cmd.AddonSpecs))
+	for i, spec := range cmd.AddonSpecs {
+		addonCost, err := s.priceAddon(ctx, spec)
+		if err := collectErrors(addons, i, err); err != nil {
+			return nil, err
+		}
+		s.addonCosts = append(s.addonCosts, addonCost)
+	}
+
+	if err := (validation.Errors{"RuntimeSpecs": runtimes.Filter(), "AddonSpecs": addons.Filter()}).Filter(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Revision returns the number of edits applied since the session started.
+func (s *RecalculationSession) Revision() int {
+	return s.revision
+}
+
+// Estimation returns the estimation of the project in its current state.
+func (s *RecalculationSession) Estimation(ctx context.Context) (*entity.CostEstimation, error) {
+	estimation := entity.NewCostEstimation(s.project.ProjectID)
+	estimation.OrganizationID = s.project.OrganizationID
+	estimation.PricedAt = s.pricedAt
+	estimation.BillingCalendar = s.calendar
+
+	// Lines are copied as taxes are set on the lines of each estimation.
+	for _, rc := range s.runtimeCosts {
+		runtimeCost := *rc
+		estimation.AddRuntimeCost(&runtimeCost)
+	}
+	for _, ac := range s.addonCosts {
+		addonCost := *ac
+		estimation.AddAddonCost(&addonCost)
+	}
+
+	if s.agreement != nil {
+		estimation.ApplyAgreement(s.agreement)
+	}
+
+	if err := s.handler.applyTax(ctx, estimation); err != nil {
+		return nil, err
+	}
+
+	if s.project.Explain {
+		estimation.Trace = traceEstimation(estimation)
+	}
+
+	return estimation, nil
+}
+
+// Apply applies an edit to the project and returns its new estimation. A
+// rejected edit leaves the project unchanged.
+func (s *RecalculationSession) Apply(ctx context.Context, edit RecalculationEdit) (*entity.CostEstimation, error) {
+	if err := edit.Validate(); err != nil {
+		return nil, err
+	}
+	if err := edit.apply(ctx, s); err != nil {
+		return nil, err
+	}
+	s.revision++
+	return s.Estimation(ctx)
+}
+
+// priceRuntime checks a runtime spec against the catalog and prices it.
+func (s *RecalculationSession) priceRuntime(ctx context.Context, spec *entity.RuntimeSpec) (*entity.RuntimeCost, error) {
+	zoneID := resolveZone(spec.ZoneID, s.zoneID)
+	if err := s.catalog.validateRuntime(ctx, zoneID, spec); err != nil {
+		return nil, err
+	}
+
+	runtimeCost, err := s.handler.calculateRuntimeCost(ctx, s.catalog, zoneID, spec, s.calendar, s.agreement, s.project.Explain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate runtime cost for %s: %w", spec.InstanceType, err)
+	}
+	return runtimeCost, nil
+}
+
+// priceAddon checks an addon spec against the catalog and prices it.
+func (s *RecalculationSession) priceAddon(ctx context.Context, spec *entity.AddonSpec) (*entity.AddonCost, error) {
+	zoneID := resolveZone(spec.ZoneID, s.zoneID)
+	if err := s.catalog.validateAddon(ctx, zoneID, spec); err != nil {
+		return nil, err
+	}
+
+	addonCost, err := s.handler.calculateAddonCost(ctx, s.catalog, zoneID, spec, s.agreement, s.project.Explain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate addon cost for %s: %w", spec.ProviderID, err)
+	}
+	return addonCost, nil
+}
+
+// checkRuntimeIndex returns a validation error unless the index is the
+// position of a runtime of the project.
+func (s *RecalculationSession) checkRuntimeIndex(index int) error {
+	if index >= len(s.project.RuntimeSpecs) {
+		return validation.Errors{
+			"RuntimeIndex": validation.NewError("validation_runtime_index_out_of_range", fmt.Sprintf("must be less than %d, the number of runtimes", len(s.project.RuntimeSpecs))),
+		}
+	}
+	return nil
+}
+
+// checkAddonIndex returns a validation error unless the index is the position
+// of an addon of the project.
+func (s *RecalculationSession) checkAddonIndex(index int) error {
+	if index >= len(s.project.AddonSpecs) {
+		return validation.Errors{
+			"AddonIndex": validation.NewError("validation_addon_index_out_of_range", fmt.Sprintf("must be less than %d, the number of addons", len(s.project.AddonSpecs))),
+		}
+	}
+	return nil
+}
+
+// fieldErrors nests the validation errors of a value under its field, and
+// returns other errors as they are.
+func fieldErrors(field string, err error) error {
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		return validation.Errors{field: errs}
+	}
+	return err
+}
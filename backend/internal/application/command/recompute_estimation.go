@@ -0,0 +1,174 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/query"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrEstimationNotPriceLocked is returned when a saved estimation does not record
+// the specs and unit prices it was computed with.
+var ErrEstimationNotPriceLocked = errors.New("estimation does not record its unit prices")
+
+// RecomputeEstimationCommand represents a command to re-price a saved estimation
+// against the current catalog.
+type RecomputeEstimationCommand struct {
+	EstimationID string
+}
+
+// Validate validates the command.
+func (c *RecomputeEstimationCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.EstimationID, validation.Required),
+	)
+}
+
+// RecomputeEstimationResult represents the result of a RecomputeEstimationCommand.
+type RecomputeEstimationResult struct {
+	// Original is the saved estimation.
+	Original *entity.CostEstimation
+	// Current is the same estimation priced with the current catalog. Lines that
+	// are no longer available are left out.
+	Current *entity.CostEstimation
+	Drift   *entity.EstimationDrift
+}
+
+// RecomputeEstimationHandler handles RecomputeEstimationCommand.
+type RecomputeEstimationHandler struct {
+	estimationRepo       repository.EstimationRepository
+	historyRepo          repository.CatalogHistoryRepository
+	calculateCostHandler *CalculateCostHandler
+}
+
+// NewRecomputeEstimationHandler creates a new RecomputeEstimationHandler.
+func NewRecomputeEstimationHandler(
+	estimationRepo repository.EstimationRepository,
+	historyRepo repository.CatalogHistoryRepository,
+	calculateCostHandler *CalculateCostHandler,
+) *RecomputeEstimationHandler {
+	return &RecomputeEstimationHandler{
+		estimationRepo:       estimationRepo,
+		historyRepo:          historyRepo,
+		calculateCostHandler: calculateCostHandler,
+	}
+}
+
+// Handle executes the RecomputeEstimationCommand. The saved estimation is left unchanged.
+func (h *RecomputeEstimationHandler) Handle(ctx context.Context, cmd *RecomputeEstimationCommand) (*RecomputeEstimationResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	original, err := h.estimationRepo.FindByID(ctx, cmd.EstimationID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, query.ErrEstimationNotFound
+	}
+	if !original.IsPriceLocked() {
+		return nil, ErrEstimationNotPriceLocked
+	}
+
+	current := entity.NewCostEstimation(original.ProjectID)
+	current.OrganizationID = original.OrganizationID
+	current.PricedAt = time.Now()
+	current.BillingCalendar = original.BillingCalendar
+	if current.BillingCalendar == nil {
+		current.BillingCalendar = h.calculateCostHandler.defaultCalendar.Resolve(original.PricedAt)
+	}
+	latest, err := h.historyRepo.FindLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		current.CatalogVersion = latest.ID
+	}
+
+	catalog := h.calculateCostHandler.newPricingCatalog()
+	drift := entity.NewEstimationDrift(original.ID, original.CatalogVersion, current.CatalogVersion)
+
+	// An estimation priced with an agreement is recomputed with the current
+	// agreement of its organization. The agreement may have changed since, the
+	// list costs of the lines tell the catalog drift apart.
+	var agreement *entity.PricingAgreement
+	if original.Agreement != nil {
+		agreement, err = h.calculateCostHandler.pricingAgreement(ctx, original.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		drift.AgreementChanged = agreement == nil || agreement.UpdatedAt.After(original.PricedAt)
+	}
+
+	for _, line := range original.RuntimeCosts {
+		runtimeDrift := &entity.RuntimeCostDrift{
+			RuntimeID:    line.RuntimeID,
+			Name:         line.Name,
+			OldUnitPrice: line.UnitPrice,
+			OldMinCost:   line.MinCost,
+			OldMaxCost:   line.MaxCost,
+			OldListCost:  line.ListCost,
+		}
+
+		runtimeCost, err := h.calculateCostHandler.calculateRuntimeCost(ctx, catalog, line.Spec.ZoneID, line.Spec, current.BillingCalendar, agreement, false)
+		if err != nil {
+			if !errors.Is(err, repository.ErrNotInCatalog) {
+				return nil, fmt.Errorf("failed to recompute runtime cost for %s: %w", line.Spec.InstanceType, err)
+			}
+			runtimeDrift.Unavailable = true
+		} else {
+			runtimeDrift.NewUnitPrice = runtimeCost.UnitPrice
+			runtimeDrift.NewMinCost = runtimeCost.MinCost
+			runtimeDrift.NewMaxCost = runtimeCost.MaxCost
+			runtimeDrift.NewListCost = runtimeCost.ListCost
+			current.AddRuntimeCost(runtimeCost)
+		}
+
+		drift.RuntimeDrifts = append(drift.RuntimeDrifts, runtimeDrift)
+	}
+
+	for _, line := range original.AddonCosts {
+		addonDrift := &entity.AddonCostDrift{
+			AddonID:      line.AddonID,
+			Name:         line.Name,
+			OldUnitPrice: line.UnitPrice,
+			OldCost:      line.Cost,
+			OldListCost:  line.ListCost,
+		}
+
+		addonCost, err := h.calculateCostHandler.calculateAddonCost(ctx, catalog, line.Spec.ZoneID, line.Spec, agreement, false)
+		if err != nil {
+			if !errors.Is(err, repository.ErrNotInCatalog) {
+				return nil, fmt.Errorf("failed to recompute addon cost for %s: %w", line.Spec.ProviderID, err)
+			}
+			addonDrift.Unavailable = true
+		} else {
+			addonDrift.NewUnitPrice = addonCost.UnitPrice
+			addonDrift.NewCost = addonCost.Cost
+			addonDrift.NewListCost = addonCost.ListCost
+			current.AddAddonCost(addonCost)
+		}
+
+		drift.AddonDrifts = append(drift.AddonDrifts, addonDrift)
+	}
+
+	if agreement != nil {
+		current.ApplyAgreement(agreement)
+	}
+	if err := h.calculateCostHandler.applyTax(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return &RecomputeEstimationResult{
+		Original: original,
+		Current:  current,
+		Drift:    drift,
+	}, nil
+}
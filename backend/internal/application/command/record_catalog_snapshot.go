@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/query"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// RecordCatalogSnapshotCommand represents a command to record the current catalog in the history.
+type RecordCatalogSnapshotCommand struct{}
+
+// RecordCatalogSnapshotResult represents the result of a RecordCatalogSnapshotCommand.
+type RecordCatalogSnapshotResult struct {
+	// SnapshotID is the ID of the snapshot matching the current catalog.
+	SnapshotID string
+	// Recorded is false when the catalog did not change since the latest snapshot.
+	Recorded bool
+}
+
+// RecordCatalogSnapshotHandler handles RecordCatalogSnapshotCommand.
+type RecordCatalogSnapshotHandler struct {
+	exportCatalogHandler *query.ExportCatalogHandler
+	historyRepo          repository.CatalogHistoryRepository
+}
+
+// NewRecordCatalogSnapshotHandler creates a new RecordCatalogSnapshotHandler.
+func NewRecordCatalogSnapshotHandler(
+	exportCatalogHandler *query.ExportCatalogHandler,
+	historyRepo repository.CatalogHistoryRepository,
+) *RecordCatalogSnapshotHandler {
+	return &RecordCatalogSnapshotHandler{
+		exportCatalogHandler: exportCatalogHandler,
+		historyRepo:          historyRepo,
+	}
+}
+
+// Handle executes the RecordCatalogSnapshotCommand. A snapshot is only stored
+// when its content differs from the latest recorded one.
+func (h *RecordCatalogSnapshotHandler) Handle(ctx context.Context, cmd *RecordCatalogSnapshotCommand) (*RecordCatalogSnapshotResult, error) {
+	exported, err := h.exportCatalogHandler.Handle(ctx, &query.ExportCatalogQuery{})
+	if err != nil {
+		return nil, err
+	}
+	snapshot := exported.Snapshot
+
+	latest, err := h.historyRepo.FindLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if latest != nil && latest.Checksum() == snapshot.Checksum() {
+		return &RecordCatalogSnapshotResult{
+			SnapshotID: latest.ID,
+			Recorded:   false,
+		}, nil
+	}
+
+	id, err := h.historyRepo.Save(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordCatalogSnapshotResult{
+		SnapshotID: id,
+		Recorded:   true,
+	}, nil
+}
@@ -0,0 +1,299 @@
+package command
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+// runtimeCostBreakdown holds the monthly costs of a runtime computed from its schedule.
+type runtimeCostBreakdown struct {
+	baseFlavorName  string
+	baseHourlyPrice entity.Money
+	baseCost        entity.Money
+	estimatedCost   entity.Money
+	scalingCost     entity.Money
+	minCost         entity.Money
+	maxCost         entity.Money
+
+	// Schedule statistics, per week
+	scalingHours          int32
+	averageLoadLevel      float64
+	scalingHoursByProfile map[string]int32
+}
+
+// runtimePricer prices the hours of a normalized runtime spec with the flavors of its instance.
+type runtimePricer struct {
+	spec             *entity.RuntimeSpec
+	scalingSimulator *service.ScalingSimulator
+	flavorPrices     map[string]entity.Money
+	availableFlavors []*entity.Flavor
+	baseHourlyPrice  entity.Money
+	baseInstances    int64
+	defaultProfile   *entity.ScalingProfile
+}
+
+func (h *CalculateCostHandler) newRuntimePricer(spec *entity.RuntimeSpec, instance *entity.Instance) *runtimePricer {
+	p := &runtimePricer{
+		spec:             spec,
+		scalingSimulator: h.scalingSimulator,
+		flavorPrices:     make(map[string]entity.Money, len(instance.Flavors)),
+		availableFlavors: instance.GetAvailableFlavors(),
+		baseInstances:    int64(spec.Baseline.Instances),
+		defaultProfile:   spec.DefaultProfile(),
+	}
+	for _, f := range instance.Flavors {
+		p.flavorPrices[f.Name] = f.PricePerHour
+	}
+	p.baseHourlyPrice = p.priceOr(spec.Baseline.FlavorName, entity.ZeroMoney(entity.DefaultCurrency))
+	return p
+}
+
+func (p *runtimePricer) priceOr(flavorName string, fallback entity.Money) entity.Money {
+	if price, ok := p.flavorPrices[flavorName]; ok {
+		return price
+	}
+	return fallback
+}
+
+// slotProfile returns the profile a schedule slot runs: its own enabled
+// profile, the default profile otherwise, or nil for the baseline.
+func (p *runtimePricer) slotProfile(slot entity.HourlyConfig) *entity.ScalingProfile {
+	if !p.spec.ScalingEnabled {
+		return nil
+	}
+	if slot.ProfileID != "" {
+		if profile := p.spec.FindEnabledProfile(slot.ProfileID); profile != nil {
+			return profile
+		}
+	}
+	return p.defaultProfile
+}
+
+// hourlyCost returns the hourly cost of a profile at a load level, or of the
+// baseline when the profile is nil.
+func (p *runtimePricer) hourlyCost(profile *entity.ScalingProfile, loadLevel int32) entity.Money {
+	if profile == nil {
+		return p.baseHourlyPrice.Times(p.baseInstances)
+	}
+
+	if len(p.availableFlavors) > 0 {
+		return p.scalingSimulator.StateAtLevel(profile, loadLevel, p.availableFlavors).HourlyCost
+	}
+
+	// Without flavor details, interpolate between the profile bounds
+	minCost := p.priceOr(profile.MinFlavorName, p.baseHourlyPrice).Times(int64(profile.MinInstances))
+	if loadLevel == entity.MinLoadLevel {
+		return minCost
+	}
+	maxCost := p.priceOr(profile.MaxFlavorName, p.baseHourlyPrice).Times(int64(profile.MaxInstances))
+	return minCost.Add(maxCost.Sub(minCost).MulRatio(int64(loadLevel), entity.MaxLoadLevel))
+}
+
+// state returns the configuration of a profile at a load level, or of the
+// baseline when the profile is nil. Without flavor details only the bounds of
+// the profile are known, so levels below the maximum report its minimum.
+func (p *runtimePricer) state(profile *entity.ScalingProfile, loadLevel int32) *entity.ScalingState {
+	if profile != nil && len(p.availableFlavors) > 0 {
+		return p.scalingSimulator.StateAtLevel(profile, loadLevel, p.availableFlavors)
+	}
+
+	st := &entity.ScalingState{
+		LoadLevel:  loadLevel,
+		HourlyCost: p.hourlyCost(profile, loadLevel),
+	}
+	switch {
+	case profile == nil:
+		st.FlavorName = p.spec.Baseline.FlavorName
+		st.Instances = p.spec.Baseline.Instances
+	case loadLevel >= entity.MaxLoadLevel:
+		st.FlavorName = profile.MaxFlavorName
+		st.Instances = profile.MaxInstances
+	default:
+		st.FlavorName = profile.MinFlavorName
+		st.Instances = profile.MinInstances
+	}
+	return st
+}
+
+// slotState returns the profile a schedule slot runs, nil for the baseline,
+// and its configuration at the load level of the slot.
+func (p *runtimePricer) slotState(slot entity.HourlyConfig) (*entity.ScalingProfile, *entity.ScalingState) {
+	profile := p.slotProfile(slot)
+	level := min(max(slot.LoadLevel, entity.MinLoadLevel), entity.MaxLoadLevel)
+	if profile == nil {
+		level = entity.MinLoadLevel
+	}
+	return profile, p.state(profile, level)
+}
+
+// loadLevelTrace returns the trace of the hours set like a slot, without hours yet.
+func (p *runtimePricer) loadLevelTrace(slot entity.HourlyConfig, hourlyCost entity.Money) *entity.LoadLevelTrace {
+	profile, state := p.slotState(slot)
+	level := &entity.LoadLevelTrace{
+		ScheduledProfileID: slot.ProfileID,
+		ScheduledLoadLevel: slot.LoadLevel,
+		LoadLevel:          state.LoadLevel,
+		FlavorName:         state.FlavorName,
+		Instances:          state.Instances,
+		HourlyCost:         hourlyCost,
+		WeeklyCost:         entity.ZeroMoney(entity.DefaultCurrency),
+	}
+	if profile != nil {
+		level.ProfileID = profile.ID
+	}
+	return level
+}
+
+// maxHourlyCost returns the hourly cost of a profile at full scale.
+func (p *runtimePricer) maxHourlyCost(profile *entity.ScalingProfile) entity.Money {
+	if len(p.availableFlavors) > 0 {
+		return p.scalingSimulator.MaxHourlyCost(profile, p.availableFlavors, p.spec.Baseline.FlavorName)
+	}
+	return p.priceOr(profile.MaxFlavorName, p.baseHourlyPrice).Times(int64(profile.MaxInstances))
+}
+
+// estimateRuntimeCost computes the monthly costs of a normalized runtime spec
+// in the given billing calendar. Hourly costs are exact, monthly costs are
+// rounded to the cent.
+//
+// Without scaling the baseline runs all month. With scaling, each hour of the
+// week is priced at the load level of its profile (the default profile for
+// baseline slots), the minimum is the default profile at level 0 all week and
+// the maximum is the most expensive profile at full scale all month.
+//
+// When trace is not nil, the schedule load levels and each computation are
+// appended to it.
+func (h *CalculateCostHandler) estimateRuntimeCost(spec *entity.RuntimeSpec, instance *entity.Instance, calendar *entity.BillingCalendar, trace *entity.RuntimeCostTrace) *runtimeCostBreakdown {
+	zero := entity.ZeroMoney(entity.DefaultCurrency)
+	pricer := h.newRuntimePricer(spec, instance)
+	hours := calendar.BilledHoursPerMonth()
+
+	baseFlavorName := spec.Baseline.FlavorName
+	baseHourlyPrice := pricer.baseHourlyPrice
+	baseHourlyCost := pricer.hourlyCost(nil, entity.MinLoadLevel)
+	baseMonthlyCost := calendar.MonthlyCost(baseHourlyCost).RoundToCents()
+
+	if !spec.ScalingEnabled {
+		if trace != nil {
+			trace.AddStep("", fmt.Sprintf("Hourly cost of the baseline, %s", baseFlavorName),
+				fmt.Sprintf("%d × %s", spec.Baseline.Instances, baseHourlyPrice), baseHourlyCost)
+			trace.AddStep("base_cost", "Monthly cost rounded to the cent, also the min, max and estimated costs without scaling",
+				fmt.Sprintf("round(%s × %d h)", baseHourlyCost, hours), baseMonthlyCost)
+		}
+		return &runtimeCostBreakdown{
+			baseFlavorName:  baseFlavorName,
+			baseHourlyPrice: baseHourlyPrice,
+			baseCost:        baseMonthlyCost,
+			estimatedCost:   baseMonthlyCost,
+			minCost:         baseMonthlyCost,
+			maxCost:         baseMonthlyCost,
+		}
+	}
+
+	defaultProfile := pricer.defaultProfile
+
+	// Price every hour of the week
+	totalWeeklyCost := zero
+	var scalingHours, totalLoadLevel int32
+	scalingHoursByProfile := make(map[string]int32)
+	loadLevels := make(map[entity.HourlyConfig]*entity.LoadLevelTrace)
+	for _, day := range spec.Schedule {
+		for _, slot := range day {
+			profile := pricer.slotProfile(slot)
+			if profile != nil && slot.LoadLevel > entity.MinLoadLevel {
+				scalingHours++
+				totalLoadLevel += slot.LoadLevel
+				scalingHoursByProfile[profile.ID]++
+			}
+			hourlyCost := pricer.hourlyCost(profile, slot.LoadLevel)
+			totalWeeklyCost = totalWeeklyCost.Add(hourlyCost)
+
+			if trace != nil {
+				level, ok := loadLevels[slot]
+				if !ok {
+					level = pricer.loadLevelTrace(slot, hourlyCost)
+					loadLevels[slot] = level
+					trace.LoadLevels = append(trace.LoadLevels, level)
+				}
+				level.HoursPerWeek++
+				level.WeeklyCost = level.WeeklyCost.Add(hourlyCost)
+			}
+		}
+	}
+	estimatedMonthlyCost := calendar.MonthlyCostOfWeek(totalWeeklyCost)
+	estimatedCost := estimatedMonthlyCost.RoundToCents()
+
+	// Minimum: every hour at level 0
+	minHourlyCost := pricer.hourlyCost(defaultProfile, entity.MinLoadLevel)
+	minCost := calendar.MonthlyCost(minHourlyCost).RoundToCents()
+
+	// Maximum: the most expensive profile at full scale all month
+	maxHourlyCost := zero
+	var maxProfile *entity.ScalingProfile
+	for _, profile := range spec.ScalingProfiles {
+		if !profile.Enabled {
+			continue
+		}
+		if cost := pricer.maxHourlyCost(profile); cost.Cmp(maxHourlyCost) > 0 {
+			maxHourlyCost = cost
+			maxProfile = profile
+		}
+	}
+	maxCost := minCost
+	if maxHourlyCost.Cmp(zero) > 0 {
+		maxCost = calendar.MonthlyCost(maxHourlyCost).RoundToCents()
+	}
+
+	if trace != nil {
+		trace.AddStep("", "Weekly cost of the schedule, the hours of each load level at their hourly cost", "", totalWeeklyCost)
+		trace.AddStep("", "Monthly cost of the schedule",
+			fmt.Sprintf("%s × %d h / %d h", totalWeeklyCost, hours, entity.HoursPerWeek), estimatedMonthlyCost)
+		trace.AddStep("estimated_cost", "Rounded to the cent", fmt.Sprintf("round(%s)", estimatedMonthlyCost), estimatedCost)
+
+		atRest := "baseline"
+		if defaultProfile != nil {
+			atRest = fmt.Sprintf("default profile %s at level %d", defaultProfile.ID, entity.MinLoadLevel)
+		}
+		trace.AddStep("min_cost", fmt.Sprintf("Monthly cost at rest, the %s all month, also the base cost", atRest),
+			fmt.Sprintf("round(%s × %d h)", minHourlyCost, hours), minCost)
+		if maxProfile != nil {
+			trace.AddStep("max_cost", fmt.Sprintf("Monthly cost of profile %s at full scale all month, the most expensive", maxProfile.ID),
+				fmt.Sprintf("round(%s × %d h)", maxHourlyCost, hours), maxCost)
+		} else {
+			trace.AddStep("max_cost", "No enabled profile, the min cost", "", maxCost)
+		}
+	}
+
+	averageLoadLevel := 0.0
+	if scalingHours > 0 {
+		averageLoadLevel = math.Round(float64(totalLoadLevel)/float64(scalingHours)*10) / 10
+	}
+
+	// At rest the runtime runs the minimum configuration of the default profile
+	if defaultProfile != nil {
+		baseFlavorName = defaultProfile.MinFlavorName
+		baseHourlyPrice = pricer.priceOr(baseFlavorName, baseHourlyPrice)
+	}
+
+	scalingCost := entity.MaxMoney(zero, estimatedCost.Sub(minCost))
+	if trace != nil {
+		trace.AddStep("scaling_cost", "Estimated cost above the base cost, at least 0", fmt.Sprintf("%s − %s", estimatedCost, minCost), scalingCost)
+	}
+
+	return &runtimeCostBreakdown{
+		baseFlavorName:  baseFlavorName,
+		baseHourlyPrice: baseHourlyPrice,
+		baseCost:        minCost,
+		estimatedCost:   estimatedCost,
+		scalingCost:     scalingCost,
+		minCost:         minCost,
+		maxCost:         maxCost,
+
+		scalingHours:          scalingHours,
+		averageLoadLevel:      averageLoadLevel,
+		scalingHoursByProfile: scalingHoursByProfile,
+	}
+}
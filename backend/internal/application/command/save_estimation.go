@@ -2,10 +2,13 @@ package command
 
 import (
 	"context"
-	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
 )
 
 // SaveEstimationCommand represents a command to save a cost estimation.
@@ -13,28 +16,126 @@ type SaveEstimationCommand struct {
 	Estimation *entity.CostEstimation
 }
 
+// Validate validates the command.
+func (c *SaveEstimationCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Estimation, validation.Required),
+	)
+}
+
+// SaveEstimationResult represents the result of a SaveEstimationCommand.
+type SaveEstimationResult struct {
+	EstimationID string
+	// Alerts are the budget alerts raised by the estimation.
+	Alerts []*entity.BudgetAlert
+}
+
 // SaveEstimationHandler handles SaveEstimationCommand.
 type SaveEstimationHandler struct {
-	estimationRepo repository.EstimationRepository
+	estimationRepo  repository.EstimationRepository
+	historyRepo     repository.CatalogHistoryRepository
+	budgetRepo      repository.BudgetRepository
+	budgetEvaluator *service.BudgetEvaluator
 }
 
 // NewSaveEstimationHandler creates a new SaveEstimationHandler.
-func NewSaveEstimationHandler(estimationRepo repository.EstimationRepository) *SaveEstimationHandler {
+func NewSaveEstimationHandler(
+	estimationRepo repository.EstimationRepository,
+	historyRepo repository.CatalogHistoryRepository,
+	budgetRepo repository.BudgetRepository,
+	budgetEvaluator *service.BudgetEvaluator,
+) *SaveEstimationHandler {
 	return &SaveEstimationHandler{
-		estimationRepo: estimationRepo,
+		estimationRepo:  estimationRepo,
+		historyRepo:     historyRepo,
+		budgetRepo:      budgetRepo,
+		budgetEvaluator: budgetEvaluator,
 	}
 }
 
-// Handle executes the SaveEstimationCommand and returns the saved estimation ID.
-func (h *SaveEstimationHandler) Handle(ctx context.Context, cmd *SaveEstimationCommand) (string, error) {
-	if cmd.Estimation == nil {
-		return "", errors.New("estimation is required")
+// Handle executes the SaveEstimationCommand.
+// The estimation is stamped with the catalog version in effect when it was
+// priced, so that it can be recomputed later. When it belongs to an
+// organization, an alert is recorded for each budget whose expected cost it
+// pushes over a threshold.
+func (h *SaveEstimationHandler) Handle(ctx context.Context, cmd *SaveEstimationCommand) (*SaveEstimationResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cmd.Estimation.PricedAt.IsZero() {
+		cmd.Estimation.PricedAt = time.Now()
+	}
+	if cmd.Estimation.CatalogVersion == "" {
+		snapshot, err := h.historyRepo.FindAt(ctx, cmd.Estimation.PricedAt)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			cmd.Estimation.CatalogVersion = snapshot.ID
+		}
+	}
+
+	var previous []*entity.CostEstimation
+	if cmd.Estimation.OrganizationID != "" {
+		var err error
+		previous, err = h.estimationRepo.FindByOrganizationID(ctx, cmd.Estimation.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	id, err := h.estimationRepo.Save(ctx, cmd.Estimation)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	result := &SaveEstimationResult{EstimationID: id}
+	if cmd.Estimation.OrganizationID == "" {
+		return result, nil
+	}
+
+	result.Alerts, err = h.raiseBudgetAlerts(ctx, id, cmd.Estimation, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// raiseBudgetAlerts records an alert for the project and organization budgets
+// whose expected cost reaches a higher status with the saved estimation.
+func (h *SaveEstimationHandler) raiseBudgetAlerts(ctx context.Context, id string, saved *entity.CostEstimation, previous []*entity.CostEstimation) ([]*entity.BudgetAlert, error) {
+	current := append(previous[:len(previous):len(previous)], saved)
+
+	// Project budget first, then the organization budget
+	scopes := []string{""}
+	if saved.ProjectID != "" {
+		scopes = []string{saved.ProjectID, ""}
+	}
+
+	var alerts []*entity.BudgetAlert
+	for _, projectID := range scopes {
+		budget, err := h.budgetRepo.FindBudget(ctx, saved.OrganizationID, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if budget == nil {
+			continue
+		}
+
+		before := h.budgetEvaluator.Evaluate(budget, previous)
+		after := h.budgetEvaluator.Evaluate(budget, current)
+		if after.Expected.Status.Severity() <= before.Expected.Status.Severity() {
+			continue
+		}
+
+		alert := entity.NewBudgetAlert(after, id)
+		if err := h.budgetRepo.SaveAlert(ctx, alert); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
 	}
 
-	return id, nil
+	return alerts, nil
 }
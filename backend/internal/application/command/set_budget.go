@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrInvalidBudget is returned when a budget is invalid.
+var ErrInvalidBudget = errors.New("invalid budget")
+
+// SetBudgetCommand represents a command to set the monthly budget of an
+// organization, or of one of its projects when ProjectID is set.
+type SetBudgetCommand struct {
+	OrganizationID string
+	ProjectID      string
+	MonthlyLimit   entity.Money
+	// Thresholds are ratios of MonthlyLimit, zero for their default value.
+	WarningThreshold  float64
+	CriticalThreshold float64
+}
+
+// Validate validates the command. The thresholds are checked against each
+// other once defaulted, by entity.NewBudget.
+func (c *SetBudgetCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.MonthlyLimit, validation.By(entity.PositiveMoney)),
+		validation.Field(&c.WarningThreshold, validation.Min(0.0)),
+		validation.Field(&c.CriticalThreshold, validation.Min(0.0)),
+	)
+}
+
+// SetBudgetHandler handles SetBudgetCommand.
+type SetBudgetHandler struct {
+	budgetRepo repository.BudgetRepository
+}
+
+// NewSetBudgetHandler creates a new SetBudgetHandler.
+func NewSetBudgetHandler(budgetRepo repository.BudgetRepository) *SetBudgetHandler {
+	return &SetBudgetHandler{
+		budgetRepo: budgetRepo,
+	}
+}
+
+// Handle executes the SetBudgetCommand and returns the saved budget.
+func (h *SetBudgetHandler) Handle(ctx context.Context, cmd *SetBudgetCommand) (*entity.Budget, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget, err := entity.NewBudget(cmd.OrganizationID, cmd.ProjectID, cmd.MonthlyLimit, cmd.WarningThreshold, cmd.CriticalThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidBudget)
+	}
+
+	if err := h.budgetRepo.SaveBudget(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
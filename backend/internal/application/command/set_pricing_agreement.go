@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrInvalidPricingAgreement is returned when a pricing agreement is invalid.
+var ErrInvalidPricingAgreement = errors.New("invalid pricing agreement")
+
+// SetPricingAgreementCommand represents a command to set the pricing agreement of an organization.
+type SetPricingAgreementCommand struct {
+	OrganizationID       string
+	CreditBalance        entity.Money
+	MonthlyCommitment    entity.Money
+	DiscountPercent      float64
+	FlavorPriceOverrides []*entity.FlavorPriceOverride
+}
+
+// Validate validates the command.
+func (c *SetPricingAgreementCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.CreditBalance, validation.By(entity.NonNegativeMoney)),
+		validation.Field(&c.MonthlyCommitment, validation.By(entity.NonNegativeMoney)),
+		validation.Field(&c.DiscountPercent, validation.Min(0.0), validation.Max(100.0)),
+		validation.Field(&c.FlavorPriceOverrides),
+	)
+}
+
+// SetPricingAgreementHandler handles SetPricingAgreementCommand.
+type SetPricingAgreementHandler struct {
+	agreementRepo repository.PricingAgreementRepository
+}
+
+// NewSetPricingAgreementHandler creates a new SetPricingAgreementHandler.
+func NewSetPricingAgreementHandler(agreementRepo repository.PricingAgreementRepository) *SetPricingAgreementHandler {
+	return &SetPricingAgreementHandler{
+		agreementRepo: agreementRepo,
+	}
+}
+
+// Handle executes the SetPricingAgreementCommand and returns the saved agreement.
+// Estimations of the organization are priced with it from then on.
+func (h *SetPricingAgreementHandler) Handle(ctx context.Context, cmd *SetPricingAgreementCommand) (*entity.PricingAgreement, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	agreement, err := entity.NewPricingAgreement(cmd.OrganizationID, cmd.CreditBalance, cmd.MonthlyCommitment, cmd.DiscountPercent, cmd.FlavorPriceOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidPricingAgreement)
+	}
+
+	if err := h.agreementRepo.SavePricingAgreement(ctx, agreement); err != nil {
+		return nil, err
+	}
+
+	return agreement, nil
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrInvalidTaxProfile is returned when a tax profile is invalid.
+var ErrInvalidTaxProfile = errors.New("invalid tax profile")
+
+// SetTaxProfileCommand represents a command to set the tax profile of an organization.
+type SetTaxProfileCommand struct {
+	OrganizationID  string
+	Country         string
+	VATNumber       string
+	Exempt          bool
+	ExemptionReason string
+}
+
+// Validate validates the command. The country and VAT number formats are
+// checked once normalized, by entity.NewTaxProfile.
+func (c *SetTaxProfileCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.Country, validation.Required),
+		validation.Field(&c.ExemptionReason, validation.When(c.Exempt, validation.Required)),
+	)
+}
+
+// SetTaxProfileHandler handles SetTaxProfileCommand.
+type SetTaxProfileHandler struct {
+	taxProfileRepo repository.TaxProfileRepository
+}
+
+// NewSetTaxProfileHandler creates a new SetTaxProfileHandler.
+func NewSetTaxProfileHandler(taxProfileRepo repository.TaxProfileRepository) *SetTaxProfileHandler {
+	return &SetTaxProfileHandler{
+		taxProfileRepo: taxProfileRepo,
+	}
+}
+
+// Handle executes the SetTaxProfileCommand and returns the saved profile.
+// Estimations of the organization are taxed with it from then on.
+func (h *SetTaxProfileHandler) Handle(ctx context.Context, cmd *SetTaxProfileCommand) (*entity.TaxProfile, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile, err := entity.NewTaxProfile(cmd.OrganizationID, cmd.Country, cmd.VATNumber, cmd.Exempt, cmd.ExemptionReason)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidTaxProfile)
+	}
+
+	if err := h.taxProfileRepo.SaveTaxProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+// Cost simulation limits.
+const (
+	DefaultSimulationIterations = 1000
+	MaxSimulationIterations     = 100000
+	DefaultHistogramBuckets     = 20
+	MaxHistogramBuckets         = 200
+)
+
+// SimulateCostCommand represents a command to simulate the monthly cost of a
+// project under uncertain load.
+type SimulateCostCommand struct {
+	ProjectID       string
+	ZoneID          string
+	RuntimeSpecs    []*entity.RuntimeSpec
+	AddonSpecs      []*entity.AddonSpec
+	BillingCalendar *entity.BillingCalendar
+	// LoadModels are the load distributions of the runtimes, by index of
+	// RuntimeSpecs. Runtimes without a model keep their scheduled load levels.
+	LoadModels []*entity.WeeklyLoadModel
+	// Iterations defaults to DefaultSimulationIterations.
+	Iterations int
+	Seed       int64
+	// HistogramBuckets defaults to DefaultHistogramBuckets.
+	HistogramBuckets int
+}
+
+// Validate validates the command on its own, the runtimes are checked against
+// the catalog by the handler.
+func (c *SimulateCostCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RuntimeSpecs),
+		validation.Field(&c.AddonSpecs),
+		validation.Field(&c.LoadModels, validation.By(c.checkLoadModelCount)),
+		validation.Field(&c.Iterations, validation.Min(0), validation.Max(MaxSimulationIterations)),
+		validation.Field(&c.HistogramBuckets, validation.Min(0), validation.Max(MaxHistogramBuckets)),
+	)
+}
+
+// checkLoadModelCount checks that there is no more load models than runtimes.
+func (c *SimulateCostCommand) checkLoadModelCount(interface{}) error {
+	if len(c.LoadModels) > len(c.RuntimeSpecs) {
+		return validation.NewError("validation_too_many_load_models", fmt.Sprintf("must have at most %d load models, one per runtime", len(c.RuntimeSpecs)))
+	}
+	return nil
+}
+
+// SimulateCostHandler handles SimulateCostCommand.
+type SimulateCostHandler struct {
+	calculateCostHandler *CalculateCostHandler
+	costSimulator        *service.CostSimulator
+}
+
+// NewSimulateCostHandler creates a new SimulateCostHandler.
+func NewSimulateCostHandler(
+	calculateCostHandler *CalculateCostHandler,
+	costSimulator *service.CostSimulator,
+) *SimulateCostHandler {
+	return &SimulateCostHandler{
+		calculateCostHandler: calculateCostHandler,
+		costSimulator:        costSimulator,
+	}
+}
+
+// Handle executes the SimulateCostCommand and returns the simulated cost distribution.
+func (h *SimulateCostHandler) Handle(ctx context.Context, cmd *SimulateCostCommand) (*entity.CostSimulation, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	zoneID := cmd.ZoneID
+	if zoneID == "" {
+		zoneID = entity.DefaultZoneID
+	}
+	catalog := h.calculateCostHandler.newPricingCatalog()
+	if err := catalog.validate(ctx, zoneID, cmd.RuntimeSpecs, cmd.AddonSpecs); err != nil {
+		return nil, err
+	}
+
+	iterations, buckets := simulationParams(cmd)
+	calendar := h.calculateCostHandler.billingCalendar(cmd.BillingCalendar).Resolve(time.Now())
+
+	runtimes := make([]*service.RuntimeLoadCosts, 0, len(cmd.RuntimeSpecs))
+	for i, spec := range cmd.RuntimeSpecs {
+		var loadModel *entity.WeeklyLoadModel
+		if i < len(cmd.LoadModels) {
+			loadModel = cmd.LoadModels[i]
+		}
+
+		rt, err := h.runtimeLoadCosts(ctx, catalog, resolveZone(spec.ZoneID, zoneID), spec, loadModel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price runtime %s: %w", spec.InstanceType, err)
+		}
+		runtimes = append(runtimes, rt)
+	}
+
+	// Addons do not depend on load, they add a fixed monthly cost
+	fixedCost := entity.ZeroMoney(entity.DefaultCurrency)
+	for _, spec := range cmd.AddonSpecs {
+		addonCost, err := h.calculateCostHandler.calculateAddonCost(ctx, catalog, resolveZone(spec.ZoneID, zoneID), spec, nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate addon cost for %s: %w", spec.ProviderID, err)
+		}
+		fixedCost = fixedCost.Add(addonCost.Cost)
+	}
+
+	return h.costSimulator.Simulate(ctx, runtimes, fixedCost, calendar, iterations, cmd.Seed, buckets)
+}
+
+// runtimeLoadCosts prices every slot of a runtime schedule at every load level.
+func (h *SimulateCostHandler) runtimeLoadCosts(ctx context.Context, catalog *pricingCatalog, zoneID string, spec *entity.RuntimeSpec, loadModel *entity.WeeklyLoadModel) (*service.RuntimeLoadCosts, error) {
+	normalized, instance, err := catalog.runtime(ctx, zoneID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pricer := h.calculateCostHandler.newRuntimePricer(normalized, instance)
+	rt := &service.RuntimeLoadCosts{LoadModel: loadModel}
+	for day := range normalized.Schedule {
+		for hour, slot := range normalized.Schedule[day] {
+			profile := pricer.slotProfile(slot)
+			for level := int32(entity.MinLoadLevel); level <= entity.MaxLoadLevel; level++ {
+				rt.SlotCosts[day][hour][level] = pricer.hourlyCost(profile, level)
+			}
+			rt.LoadLevels[day][hour] = min(max(slot.LoadLevel, entity.MinLoadLevel), entity.MaxLoadLevel)
+		}
+	}
+
+	return rt, nil
+}
+
+// simulationParams returns the number of iterations and histogram buckets of
+// a validated command.
+func simulationParams(cmd *SimulateCostCommand) (int, int) {
+	iterations := cmd.Iterations
+	if iterations == 0 {
+		iterations = DefaultSimulationIterations
+	}
+
+	buckets := cmd.HistogramBuckets
+	if buckets == 0 {
+		buckets = DefaultHistogramBuckets
+	}
+
+	return iterations, buckets
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+// SuggestOptimizationsCommand represents a command to suggest cheaper
+// configurations of the runtimes of a project.
+type SuggestOptimizationsCommand struct {
+	ProjectID string
+	// OrganizationID selects the pricing agreement the runtimes are priced with.
+	OrganizationID string
+	ZoneID         string
+	RuntimeSpecs   []*entity.RuntimeSpec
+	// Staging marks a project that only needs to run during business hours.
+	Staging bool
+}
+
+// Validate validates the command on its own, the runtimes are checked against
+// the catalog by the handler.
+func (c *SuggestOptimizationsCommand) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RuntimeSpecs),
+	)
+}
+
+// SuggestOptimizationsHandler handles SuggestOptimizationsCommand.
+type SuggestOptimizationsHandler struct {
+	calculateCostHandler *CalculateCostHandler
+	optimizationAdvisor  *service.OptimizationAdvisor
+}
+
+// NewSuggestOptimizationsHandler creates a new SuggestOptimizationsHandler.
+func NewSuggestOptimizationsHandler(calculateCostHandler *CalculateCostHandler, optimizationAdvisor *service.OptimizationAdvisor) *SuggestOptimizationsHandler {
+	return &SuggestOptimizationsHandler{
+		calculateCostHandler: calculateCostHandler,
+		optimizationAdvisor:  optimizationAdvisor,
+	}
+}
+
+// Handle executes the SuggestOptimizationsCommand. Each runtime and each of
+// its alternatives is priced like CalculateCost, and only alternatives with a
+// lower estimated monthly cost are suggested.
+func (h *SuggestOptimizationsHandler) Handle(ctx context.Context, cmd *SuggestOptimizationsCommand) (*entity.OptimizationReport, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	zoneID := cmd.ZoneID
+	if zoneID == "" {
+		zoneID = entity.DefaultZoneID
+	}
+	catalog := h.calculateCostHandler.newPricingCatalog()
+	if err := catalog.validate(ctx, zoneID, cmd.RuntimeSpecs, nil); err != nil {
+		return nil, err
+	}
+
+	calendar := h.calculateCostHandler.billingCalendar(nil).Resolve(time.Now())
+	agreement, err := h.calculateCostHandler.pricingAgreement(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := entity.ZeroMoney(entity.DefaultCurrency)
+	report := &entity.OptimizationReport{
+		Suggestions:        make([]*entity.OptimizationSuggestion, 0),
+		CurrentMonthlyCost: zero,
+		BestMonthlySaving:  zero,
+	}
+
+	for i, spec := range cmd.RuntimeSpecs {
+		runtimeZoneID := resolveZone(spec.ZoneID, zoneID)
+		current, err := h.calculateCostHandler.calculateRuntimeCost(ctx, catalog, runtimeZoneID, spec, calendar, agreement, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price runtime %s: %w", spec.InstanceType, err)
+		}
+		report.CurrentMonthlyCost = report.CurrentMonthlyCost.Add(current.EstimatedCost)
+
+		instance, err := catalog.instance(ctx, runtimeZoneID, spec.InstanceType)
+		if err != nil {
+			return nil, err
+		}
+		if agreement != nil {
+			instance = agreement.ApplyFlavorPriceOverrides(instance)
+		}
+		currentCapacity := h.capacity(current.Spec, instance)
+
+		bestSaving := zero
+		for _, candidate := range h.optimizationAdvisor.Candidates(current.Spec, instance, cmd.Staging) {
+			suggested, err := h.calculateCostHandler.calculateRuntimeCost(ctx, catalog, runtimeZoneID, candidate.Spec, calendar, agreement, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to price suggestion for runtime %s: %w", spec.InstanceType, err)
+			}
+
+			saving := current.EstimatedCost.Sub(suggested.EstimatedCost)
+			if saving.Cmp(zero) <= 0 {
+				continue
+			}
+			bestSaving = entity.MaxMoney(bestSaving, saving)
+
+			report.Suggestions = append(report.Suggestions, &entity.OptimizationSuggestion{
+				Kind:                 candidate.Kind,
+				RuntimeIndex:         i,
+				InstanceType:         spec.InstanceType,
+				Description:          candidate.Description,
+				SuggestedSpec:        suggested.Spec,
+				CurrentMonthlyCost:   current.EstimatedCost,
+				SuggestedMonthlyCost: suggested.EstimatedCost,
+				MonthlySaving:        saving,
+				CurrentCapacity:      currentCapacity,
+				SuggestedCapacity:    h.capacity(suggested.Spec, instance),
+			})
+		}
+		report.BestMonthlySaving = report.BestMonthlySaving.Add(bestSaving)
+	}
+
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		return report.Suggestions[i].MonthlySaving.Cmp(report.Suggestions[j].MonthlySaving) > 0
+	})
+
+	return report, nil
+}
+
+// capacity returns the lowest and highest CPUs and memory a normalized
+// runtime spec runs over the hours of its schedule.
+func (h *SuggestOptimizationsHandler) capacity(spec *entity.RuntimeSpec, instance *entity.Instance) entity.CapacityRange {
+	pricer := h.calculateCostHandler.newRuntimePricer(spec, instance)
+
+	capacity := entity.CapacityRange{
+		Min: entity.ResourceCapacity{CPUs: math.MaxInt32, Mem: math.MaxInt32},
+	}
+	for _, day := range spec.Schedule {
+		for _, slot := range day {
+			_, state := pricer.slotState(slot)
+
+			var c entity.ResourceCapacity
+			if flavor := instance.FindFlavorByName(state.FlavorName); flavor != nil {
+				c = entity.NewResourceCapacity(flavor, state.Instances)
+			}
+
+			capacity.Min.CPUs = min(capacity.Min.CPUs, c.CPUs)
+			capacity.Min.Mem = min(capacity.Min.Mem, c.Mem)
+			capacity.Max.CPUs = max(capacity.Max.CPUs, c.CPUs)
+			capacity.Max.Mem = max(capacity.Max.Mem, c.Mem)
+		}
+	}
+	return capacity
+}
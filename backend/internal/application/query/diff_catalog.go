@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrCatalogSnapshotNotFound is returned when a catalog snapshot is not found.
+var ErrCatalogSnapshotNotFound = errors.New("catalog snapshot not found")
+
+// CatalogRef selects a catalog snapshot, either by ID or as the one in effect at a given time.
+// The zero value selects the latest snapshot.
+type CatalogRef struct {
+	SnapshotID string
+	At         time.Time
+}
+
+// DiffCatalogQuery represents a query to compare two catalog snapshots.
+type DiffCatalogQuery struct {
+	From CatalogRef
+	To   CatalogRef
+}
+
+// DiffCatalogResult represents the result of a DiffCatalogQuery.
+type DiffCatalogResult struct {
+	Diff *entity.CatalogDiff
+}
+
+// DiffCatalogHandler handles DiffCatalogQuery.
+type DiffCatalogHandler struct {
+	historyRepo repository.CatalogHistoryRepository
+}
+
+// NewDiffCatalogHandler creates a new DiffCatalogHandler.
+func NewDiffCatalogHandler(historyRepo repository.CatalogHistoryRepository) *DiffCatalogHandler {
+	return &DiffCatalogHandler{
+		historyRepo: historyRepo,
+	}
+}
+
+// Handle executes the DiffCatalogQuery.
+func (h *DiffCatalogHandler) Handle(ctx context.Context, query *DiffCatalogQuery) (*DiffCatalogResult, error) {
+	from, err := h.resolve(ctx, query.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := h.resolve(ctx, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffCatalogResult{
+		Diff: entity.DiffCatalogSnapshots(from, to),
+	}, nil
+}
+
+func (h *DiffCatalogHandler) resolve(ctx context.Context, ref CatalogRef) (*entity.CatalogSnapshot, error) {
+	var snapshot *entity.CatalogSnapshot
+	var err error
+
+	switch {
+	case ref.SnapshotID != "":
+		snapshot, err = h.historyRepo.FindByID(ctx, ref.SnapshotID)
+	case !ref.At.IsZero():
+		snapshot, err = h.historyRepo.FindAt(ctx, ref.At)
+	default:
+		snapshot, err = h.historyRepo.FindLatest(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot == nil {
+		return nil, ErrCatalogSnapshotNotFound
+	}
+
+	return snapshot, nil
+}
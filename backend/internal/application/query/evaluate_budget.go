@@ -0,0 +1,72 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+// ErrBudgetNotFound is returned when an organization or project has no budget.
+var ErrBudgetNotFound = errors.New("budget not found")
+
+// EvaluateBudgetQuery represents a query to evaluate the saved estimations of
+// an organization, or of one of its projects, against its budget.
+type EvaluateBudgetQuery struct {
+	OrganizationID string
+	// ProjectID selects a project budget instead of the organization budget.
+	ProjectID string
+}
+
+// Validate validates the query.
+func (q *EvaluateBudgetQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.OrganizationID, validation.Required),
+	)
+}
+
+// EvaluateBudgetHandler handles EvaluateBudgetQuery.
+type EvaluateBudgetHandler struct {
+	budgetRepo      repository.BudgetRepository
+	estimationRepo  repository.EstimationRepository
+	budgetEvaluator *service.BudgetEvaluator
+}
+
+// NewEvaluateBudgetHandler creates a new EvaluateBudgetHandler.
+func NewEvaluateBudgetHandler(
+	budgetRepo repository.BudgetRepository,
+	estimationRepo repository.EstimationRepository,
+	budgetEvaluator *service.BudgetEvaluator,
+) *EvaluateBudgetHandler {
+	return &EvaluateBudgetHandler{
+		budgetRepo:      budgetRepo,
+		estimationRepo:  estimationRepo,
+		budgetEvaluator: budgetEvaluator,
+	}
+}
+
+// Handle executes the EvaluateBudgetQuery.
+func (h *EvaluateBudgetHandler) Handle(ctx context.Context, query *EvaluateBudgetQuery) (*entity.BudgetEvaluation, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget, err := h.budgetRepo.FindBudget(ctx, query.OrganizationID, query.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if budget == nil {
+		return nil, ErrBudgetNotFound
+	}
+
+	estimations, err := h.estimationRepo.FindByOrganizationID(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.budgetEvaluator.Evaluate(budget, estimations), nil
+}
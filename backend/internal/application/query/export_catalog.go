@@ -0,0 +1,67 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ExportCatalogQuery represents a query to export the current catalog as a snapshot.
+type ExportCatalogQuery struct{}
+
+// ExportCatalogResult represents the result of an ExportCatalogQuery.
+type ExportCatalogResult struct {
+	Snapshot *entity.CatalogSnapshot
+}
+
+// ExportCatalogHandler handles ExportCatalogQuery.
+type ExportCatalogHandler struct {
+	pricingRepo repository.PricingRepository
+	addonRepo   repository.AddonCatalogRepository
+	zoneRepo    repository.ZoneRepository
+}
+
+// NewExportCatalogHandler creates a new ExportCatalogHandler.
+func NewExportCatalogHandler(
+	pricingRepo repository.PricingRepository,
+	addonRepo repository.AddonCatalogRepository,
+	zoneRepo repository.ZoneRepository,
+) *ExportCatalogHandler {
+	return &ExportCatalogHandler{
+		pricingRepo: pricingRepo,
+		addonRepo:   addonRepo,
+		zoneRepo:    zoneRepo,
+	}
+}
+
+// Handle executes the ExportCatalogQuery.
+func (h *ExportCatalogHandler) Handle(ctx context.Context, query *ExportCatalogQuery) (*ExportCatalogResult, error) {
+	snapshot := entity.NewCatalogSnapshot(time.Now())
+
+	zones, err := h.zoneRepo.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Zones = zones
+
+	for _, zone := range zones {
+		instances, err := h.pricingRepo.ListInstances(ctx, zone.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export instances of zone %s: %w", zone.Name, err)
+		}
+		snapshot.Instances[zone.Name] = instances
+	}
+
+	providers, err := h.addonRepo.ListAddonProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AddonProviders = providers
+
+	return &ExportCatalogResult{
+		Snapshot: snapshot,
+	}, nil
+}
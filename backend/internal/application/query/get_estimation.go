@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
 )
@@ -16,6 +18,13 @@ type GetEstimationQuery struct {
 	EstimationID string
 }
 
+// Validate validates the query.
+func (q *GetEstimationQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.EstimationID, validation.Required),
+	)
+}
+
 // GetEstimationResult represents the result of a GetEstimationQuery.
 type GetEstimationResult struct {
 	Estimation *entity.CostEstimation
@@ -35,8 +44,8 @@ func NewGetEstimationHandler(estimationRepo repository.EstimationRepository) *Ge
 
 // Handle executes the GetEstimationQuery.
 func (h *GetEstimationHandler) Handle(ctx context.Context, query *GetEstimationQuery) (*GetEstimationResult, error) {
-	if query.EstimationID == "" {
-		return nil, errors.New("estimation ID is required")
+	if err := query.Validate(); err != nil {
+		return nil, err
 	}
 
 	estimation, err := h.estimationRepo.FindByID(ctx, query.EstimationID)
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrPricingAgreementNotFound is returned when an organization has no pricing agreement.
+var ErrPricingAgreementNotFound = errors.New("pricing agreement not found")
+
+// GetPricingAgreementQuery represents a query to get the pricing agreement of an organization.
+type GetPricingAgreementQuery struct {
+	OrganizationID string
+}
+
+// Validate validates the query.
+func (q *GetPricingAgreementQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.OrganizationID, validation.Required),
+	)
+}
+
+// GetPricingAgreementHandler handles GetPricingAgreementQuery.
+type GetPricingAgreementHandler struct {
+	agreementRepo repository.PricingAgreementRepository
+}
+
+// NewGetPricingAgreementHandler creates a new GetPricingAgreementHandler.
+func NewGetPricingAgreementHandler(agreementRepo repository.PricingAgreementRepository) *GetPricingAgreementHandler {
+	return &GetPricingAgreementHandler{
+		agreementRepo: agreementRepo,
+	}
+}
+
+// Handle executes the GetPricingAgreementQuery.
+func (h *GetPricingAgreementHandler) Handle(ctx context.Context, query *GetPricingAgreementQuery) (*entity.PricingAgreement, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	agreement, err := h.agreementRepo.FindPricingAgreement(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if agreement == nil {
+		return nil, ErrPricingAgreementNotFound
+	}
+
+	return agreement, nil
+}
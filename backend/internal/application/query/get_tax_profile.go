@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ErrTaxProfileNotFound is returned when an organization has no tax profile.
+var ErrTaxProfileNotFound = errors.New("tax profile not found")
+
+// GetTaxProfileQuery represents a query to get the tax profile of an organization.
+type GetTaxProfileQuery struct {
+	OrganizationID string
+}
+
+// Validate validates the query.
+func (q *GetTaxProfileQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.OrganizationID, validation.Required),
+	)
+}
+
+// GetTaxProfileHandler handles GetTaxProfileQuery.
+type GetTaxProfileHandler struct {
+	taxProfileRepo repository.TaxProfileRepository
+}
+
+// NewGetTaxProfileHandler creates a new GetTaxProfileHandler.
+func NewGetTaxProfileHandler(taxProfileRepo repository.TaxProfileRepository) *GetTaxProfileHandler {
+	return &GetTaxProfileHandler{
+		taxProfileRepo: taxProfileRepo,
+	}
+}
+
+// Handle executes the GetTaxProfileQuery.
+func (h *GetTaxProfileHandler) Handle(ctx context.Context, query *GetTaxProfileQuery) (*entity.TaxProfile, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile, err := h.taxProfileRepo.FindTaxProfile(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrTaxProfileNotFound
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ListAddonProvidersQuery represents a query to list all available addon providers.
+type ListAddonProvidersQuery struct{}
+
+// ListAddonProvidersResult represents the result of a ListAddonProvidersQuery.
+type ListAddonProvidersResult struct {
+	Providers []*entity.AddonProvider
+}
+
+// ListAddonProvidersHandler handles ListAddonProvidersQuery.
+type ListAddonProvidersHandler struct {
+	addonRepo repository.AddonCatalogRepository
+}
+
+// NewListAddonProvidersHandler creates a new ListAddonProvidersHandler.
+func NewListAddonProvidersHandler(addonRepo repository.AddonCatalogRepository) *ListAddonProvidersHandler {
+	return &ListAddonProvidersHandler{
+		addonRepo: addonRepo,
+	}
+}
+
+// Handle executes the ListAddonProvidersQuery.
+func (h *ListAddonProvidersHandler) Handle(ctx context.Context, query *ListAddonProvidersQuery) (*ListAddonProvidersResult, error) {
+	providers, err := h.addonRepo.ListAddonProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAddonProvidersResult{
+		Providers: providers,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ListBudgetAlertsQuery represents a query to list the budget alerts of an organization.
+type ListBudgetAlertsQuery struct {
+	OrganizationID string
+}
+
+// Validate validates the query.
+func (q *ListBudgetAlertsQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.OrganizationID, validation.Required),
+	)
+}
+
+// ListBudgetAlertsResult represents the result of a ListBudgetAlertsQuery.
+type ListBudgetAlertsResult struct {
+	// Alerts are ordered oldest first.
+	Alerts []*entity.BudgetAlert
+}
+
+// ListBudgetAlertsHandler handles ListBudgetAlertsQuery.
+type ListBudgetAlertsHandler struct {
+	budgetRepo repository.BudgetRepository
+}
+
+// NewListBudgetAlertsHandler creates a new ListBudgetAlertsHandler.
+func NewListBudgetAlertsHandler(budgetRepo repository.BudgetRepository) *ListBudgetAlertsHandler {
+	return &ListBudgetAlertsHandler{
+		budgetRepo: budgetRepo,
+	}
+}
+
+// Handle executes the ListBudgetAlertsQuery.
+func (h *ListBudgetAlertsHandler) Handle(ctx context.Context, query *ListBudgetAlertsQuery) (*ListBudgetAlertsResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	alerts, err := h.budgetRepo.ListAlerts(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListBudgetAlertsResult{
+		Alerts: alerts,
+	}, nil
+}
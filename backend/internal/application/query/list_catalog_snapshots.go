@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ListCatalogSnapshotsQuery represents a query to list the recorded catalog snapshots.
+type ListCatalogSnapshotsQuery struct{}
+
+// ListCatalogSnapshotsResult represents the result of a ListCatalogSnapshotsQuery.
+type ListCatalogSnapshotsResult struct {
+	Snapshots []*entity.CatalogSnapshot
+}
+
+// ListCatalogSnapshotsHandler handles ListCatalogSnapshotsQuery.
+type ListCatalogSnapshotsHandler struct {
+	historyRepo repository.CatalogHistoryRepository
+}
+
+// NewListCatalogSnapshotsHandler creates a new ListCatalogSnapshotsHandler.
+func NewListCatalogSnapshotsHandler(historyRepo repository.CatalogHistoryRepository) *ListCatalogSnapshotsHandler {
+	return &ListCatalogSnapshotsHandler{
+		historyRepo: historyRepo,
+	}
+}
+
+// Handle executes the ListCatalogSnapshotsQuery.
+func (h *ListCatalogSnapshotsHandler) Handle(ctx context.Context, query *ListCatalogSnapshotsQuery) (*ListCatalogSnapshotsResult, error) {
+	snapshots, err := h.historyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListCatalogSnapshotsResult{
+		Snapshots: snapshots,
+	}, nil
+}
@@ -33,7 +33,7 @@ func NewListInstancesHandler(pricingRepo repository.PricingRepository) *ListInst
 func (h *ListInstancesHandler) Handle(ctx context.Context, query *ListInstancesQuery) (*ListInstancesResult, error) {
 	zoneID := query.ZoneID
 	if zoneID == "" {
-		zoneID = "par" // Default to Paris zone
+		zoneID = entity.DefaultZoneID
 	}
 
 	instances, err := h.pricingRepo.ListInstances(ctx, zoneID)
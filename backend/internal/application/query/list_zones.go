@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+)
+
+// ListZonesQuery represents a query to list all deployment zones.
+type ListZonesQuery struct{}
+
+// ListZonesResult represents the result of a ListZonesQuery.
+type ListZonesResult struct {
+	Zones []*entity.Zone
+}
+
+// ListZonesHandler handles ListZonesQuery.
+type ListZonesHandler struct {
+	zoneRepo repository.ZoneRepository
+}
+
+// NewListZonesHandler creates a new ListZonesHandler.
+func NewListZonesHandler(zoneRepo repository.ZoneRepository) *ListZonesHandler {
+	return &ListZonesHandler{
+		zoneRepo: zoneRepo,
+	}
+}
+
+// Handle executes the ListZonesQuery.
+func (h *ListZonesHandler) Handle(ctx context.Context, query *ListZonesQuery) (*ListZonesResult, error) {
+	zones, err := h.zoneRepo.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListZonesResult{
+		Zones: zones,
+	}, nil
+}
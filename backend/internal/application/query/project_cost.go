@@ -0,0 +1,132 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+const (
+	// MaxProjectionMonths is the longest cost projection horizon.
+	MaxProjectionMonths = 120
+	// MaxMonthlyGrowthRate is the highest monthly traffic growth, 10 for 1000%.
+	MaxMonthlyGrowthRate = 10
+)
+
+// ProjectCostQuery represents a query to project the cost of an estimation
+// over several months.
+type ProjectCostQuery struct {
+	// EstimationID selects a saved estimation, unless Estimation is set.
+	EstimationID string
+	Estimation   *entity.CostEstimation
+	// Months is the projection horizon.
+	Months int
+	// StartYear and StartMonth select the first projected month, the current
+	// month when unset.
+	StartYear  int
+	StartMonth time.Month
+	// MonthlyGrowthRate is the traffic growth per month, 0.05 for 5%.
+	MonthlyGrowthRate float64
+	// SeasonalMultipliers are traffic multipliers by calendar month, January
+	// first: either none or entity.MonthsPerYear of them.
+	SeasonalMultipliers []float64
+}
+
+// Validate validates the query.
+func (q *ProjectCostQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.EstimationID, validation.When(q.Estimation == nil, validation.Required.Error("is required without an estimation"))),
+		validation.Field(&q.Months, validation.Required, validation.Min(1), validation.Max(MaxProjectionMonths)),
+		validation.Field(&q.StartMonth, validation.Min(time.January), validation.Max(time.December)),
+		validation.Field(&q.StartYear, validation.When(q.StartMonth != 0, validation.Required.Error("is required with a start month"))),
+		validation.Field(&q.MonthlyGrowthRate, validation.By(checkGrowthRate)),
+		validation.Field(&q.SeasonalMultipliers,
+			validation.Length(entity.MonthsPerYear, entity.MonthsPerYear),
+			validation.Each(validation.By(checkMultiplier)),
+		),
+	)
+}
+
+// checkGrowthRate checks that a monthly growth rate is greater than -1 and at
+// most MaxMonthlyGrowthRate.
+func checkGrowthRate(value interface{}) error {
+	rate, _ := value.(float64)
+	if rate <= -1 || rate > MaxMonthlyGrowthRate || math.IsNaN(rate) {
+		return validation.NewError("validation_growth_rate", fmt.Sprintf("must be greater than -1 and no greater than %d", MaxMonthlyGrowthRate))
+	}
+	return nil
+}
+
+// checkMultiplier checks that a seasonal multiplier is finite and not negative.
+func checkMultiplier(value interface{}) error {
+	m, _ := value.(float64)
+	if m < 0 || math.IsNaN(m) || math.IsInf(m, 0) {
+		return validation.NewError("validation_multiplier", "must not be negative")
+	}
+	return nil
+}
+
+// ProjectCostHandler handles ProjectCostQuery.
+type ProjectCostHandler struct {
+	estimationRepo  repository.EstimationRepository
+	costProjector   *service.CostProjector
+	defaultCalendar *entity.BillingCalendar
+}
+
+// NewProjectCostHandler creates a new ProjectCostHandler.
+func NewProjectCostHandler(
+	estimationRepo repository.EstimationRepository,
+	costProjector *service.CostProjector,
+	defaultCalendar *entity.BillingCalendar,
+) *ProjectCostHandler {
+	return &ProjectCostHandler{
+		estimationRepo:  estimationRepo,
+		costProjector:   costProjector,
+		defaultCalendar: defaultCalendar,
+	}
+}
+
+// Handle executes the ProjectCostQuery.
+func (h *ProjectCostHandler) Handle(ctx context.Context, query *ProjectCostQuery) (*entity.CostProjection, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	estimation := query.Estimation
+	if estimation == nil {
+		var err error
+		estimation, err = h.estimationRepo.FindByID(ctx, query.EstimationID)
+		if err != nil {
+			return nil, err
+		}
+		if estimation == nil {
+			return nil, ErrEstimationNotFound
+		}
+	}
+
+	// Monthly costs were computed with the calendar of the estimation
+	calendar := estimation.BillingCalendar
+	if calendar == nil {
+		calendar = h.defaultCalendar
+	}
+	calendar = calendar.Resolve(estimation.PricedAt)
+
+	startYear, startMonth := query.StartYear, query.StartMonth
+	if startMonth == 0 {
+		location := calendar.Location
+		if location == nil {
+			location = time.UTC
+		}
+		now := time.Now().In(location)
+		startYear, startMonth = now.Year(), now.Month()
+	}
+
+	return h.costProjector.Project(estimation, calendar, startYear, startMonth, query.Months, query.MonthlyGrowthRate, query.SeasonalMultipliers)
+}
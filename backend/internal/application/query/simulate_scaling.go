@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+)
+
+// SimulateScalingQuery represents a query to simulate a scaling profile at every load level.
+type SimulateScalingQuery struct {
+	// ZoneID defaults to entity.DefaultZoneID.
+	ZoneID       string
+	InstanceType string
+	Profile      *entity.ScalingProfile
+}
+
+// Validate validates the query on its own, the profile is checked against the
+// catalog by the handler.
+func (q *SimulateScalingQuery) Validate() error {
+	return validation.ValidateStruct(q,
+		validation.Field(&q.InstanceType, validation.Required),
+		validation.Field(&q.Profile, validation.Required, validation.By(requireProfileFlavors)),
+	)
+}
+
+// requireProfileFlavors checks that a simulated profile sets its flavor range.
+func requireProfileFlavors(value interface{}) error {
+	profile, _ := value.(*entity.ScalingProfile)
+	if profile == nil {
+		return nil
+	}
+	return validation.Errors{
+		"MinFlavorName": validation.Validate(profile.MinFlavorName, validation.Required),
+		"MaxFlavorName": validation.Validate(profile.MaxFlavorName, validation.Required),
+	}.Filter()
+}
+
+// SimulateScalingResult represents the result of a SimulateScalingQuery.
+type SimulateScalingResult struct {
+	// States holds the configuration of the profile at load levels 0 to 5.
+	States []*entity.ScalingState
+}
+
+// SimulateScalingHandler handles SimulateScalingQuery.
+type SimulateScalingHandler struct {
+	pricingRepo      repository.PricingRepository
+	scalingSimulator *service.ScalingSimulator
+}
+
+// NewSimulateScalingHandler creates a new SimulateScalingHandler.
+func NewSimulateScalingHandler(
+	pricingRepo repository.PricingRepository,
+	scalingSimulator *service.ScalingSimulator,
+) *SimulateScalingHandler {
+	return &SimulateScalingHandler{
+		pricingRepo:      pricingRepo,
+		scalingSimulator: scalingSimulator,
+	}
+}
+
+// Handle executes the SimulateScalingQuery.
+func (h *SimulateScalingHandler) Handle(ctx context.Context, query *SimulateScalingQuery) (*SimulateScalingResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	zoneID := query.ZoneID
+	if zoneID == "" {
+		zoneID = entity.DefaultZoneID
+	}
+
+	instance, err := h.pricingRepo.GetInstanceByType(ctx, zoneID, query.InstanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Profile.ValidateCatalog(instance); err != nil {
+		return nil, validation.Errors{"Profile": err}
+	}
+
+	return &SimulateScalingResult{
+		States: h.scalingSimulator.States(query.Profile, instance.Flavors),
+	}, nil
+}
@@ -1,9 +1,14 @@
 package config
 
+import "time"
+
 // Config holds all configuration for the application.
 type Config struct {
 	Server      ServerConfig
 	CleverCloud CleverCloudConfig
+	Catalog     CatalogConfig
+	Billing     BillingConfig
+	Batch       BatchConfig
 	CORS        CORSConfig
 }
 
@@ -12,17 +17,70 @@ type ServerConfig struct {
 	Host string
 	Port int
 	Env  string
+	// DebugEndpoints serves the /debug/ endpoints, unauthenticated. It
+	// defaults to true in development only.
+	DebugEndpoints bool
 }
 
 // CleverCloudConfig holds Clever Cloud API configuration.
 type CleverCloudConfig struct {
 	APIURL         string
+	APIV2URL       string
 	ConsumerKey    string
 	ConsumerSecret string
 	Token          string
 	TokenSecret    string
 }
 
+// Catalog sources.
+const (
+	CatalogSourceLive     = "live"
+	CatalogSourceSnapshot = "snapshot"
+)
+
+// CatalogConfig holds pricing catalog configuration.
+type CatalogConfig struct {
+	// Source selects where the catalog comes from: the Clever Cloud API or a snapshot file.
+	Source string
+	// SnapshotPath is the snapshot file loaded when Source is "snapshot".
+	// When empty, the snapshot embedded in the binary is used.
+	SnapshotPath string
+	// CacheTTL is how long a fetched catalog is served as fresh. Zero disables caching.
+	CacheTTL time.Duration
+	// CacheMaxStale is how long past CacheTTL a catalog may still be served
+	// while it is refreshed in the background.
+	CacheMaxStale time.Duration
+	// HistoryInterval is how often the catalog is checked for changes and
+	// recorded in the history. Zero disables recording.
+	HistoryInterval time.Duration
+	// HistoryDir is the directory where catalog history snapshots are written.
+	// When empty, the history is kept in memory and lost on restart.
+	HistoryDir string
+}
+
+// Billing calendars.
+const (
+	BillingCalendarFixed720      = "fixed_720"
+	BillingCalendarFixed730      = "fixed_730"
+	BillingCalendarCalendarMonth = "calendar_month"
+)
+
+// BillingConfig holds cost computation configuration.
+type BillingConfig struct {
+	// Calendar selects the number of hours in a billed month when a request does not.
+	Calendar string
+	// TimeZone is the IANA time zone calendar months are counted in.
+	TimeZone string
+}
+
+// BatchConfig holds batch computation configuration.
+type BatchConfig struct {
+	// MaxWorkers is the most items of a batch computed at once.
+	MaxWorkers int
+	// MaxItems is the most items a batch may contain.
+	MaxItems int
+}
+
 // CORSConfig holds CORS configuration.
 type CORSConfig struct {
 	AllowedOrigins []string
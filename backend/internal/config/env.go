@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -18,17 +20,35 @@ func Load() (*Config, error) {
 		},
 		CleverCloud: CleverCloudConfig{
 			APIURL:         getEnv("CLEVER_CLOUD_API_URL", "https://api.clever-cloud.com/v4"),
+			APIV2URL:       getEnv("CLEVER_CLOUD_API_V2_URL", "https://api.clever-cloud.com/v2"),
 			ConsumerKey:    getEnv("CLEVER_CLOUD_CONSUMER_KEY", ""),
 			ConsumerSecret: getEnv("CLEVER_CLOUD_CONSUMER_SECRET", ""),
 			Token:          getEnv("CLEVER_CLOUD_TOKEN", ""),
 			TokenSecret:    getEnv("CLEVER_CLOUD_TOKEN_SECRET", ""),
 		},
+		Catalog: CatalogConfig{
+			Source:          getEnv("CATALOG_SOURCE", CatalogSourceLive),
+			SnapshotPath:    getEnv("CATALOG_SNAPSHOT_PATH", ""),
+			CacheTTL:        getEnvDuration("CATALOG_CACHE_TTL", 15*time.Minute),
+			CacheMaxStale:   getEnvDuration("CATALOG_CACHE_MAX_STALE", time.Hour),
+			HistoryInterval: getEnvDuration("CATALOG_HISTORY_INTERVAL", time.Hour),
+			HistoryDir:      getEnv("CATALOG_HISTORY_DIR", ""),
+		},
+		Billing: BillingConfig{
+			Calendar: getEnv("BILLING_CALENDAR", BillingCalendarFixed720),
+			TimeZone: getEnv("BILLING_TIMEZONE", "Europe/Paris"),
+		},
+		Batch: BatchConfig{
+			MaxWorkers: getEnvInt("BATCH_MAX_WORKERS", 8),
+			MaxItems:   getEnvInt("BATCH_MAX_ITEMS", 100),
+		},
 		CORS: CORSConfig{
 			AllowedOrigins: getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:5173"}),
 			AllowedMethods: getEnvSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
 			AllowedHeaders: getEnvSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Connect-Protocol-Version"}),
 		},
 	}
+	cfg.Server.DebugEndpoints = getEnvBool("SERVER_DEBUG_ENDPOINTS", cfg.IsDevelopment())
 
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -41,6 +61,9 @@ func Load() (*Config, error) {
 func (c *Config) Validate() error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Server, validation.Required),
+		validation.Field(&c.Catalog),
+		validation.Field(&c.Billing),
+		validation.Field(&c.Batch),
 	)
 }
 
@@ -53,6 +76,40 @@ func (s ServerConfig) Validate() error {
 	)
 }
 
+// Validate validates the catalog configuration.
+func (c CatalogConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Source, validation.Required, validation.In(CatalogSourceLive, CatalogSourceSnapshot)),
+		validation.Field(&c.CacheTTL, validation.Min(time.Duration(0))),
+		validation.Field(&c.CacheMaxStale, validation.Min(time.Duration(0))),
+		validation.Field(&c.HistoryInterval, validation.Min(time.Duration(0))),
+	)
+}
+
+// Validate validates the billing configuration.
+func (b BillingConfig) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Calendar, validation.Required, validation.In(BillingCalendarFixed720, BillingCalendarFixed730, BillingCalendarCalendarMonth)),
+		validation.Field(&b.TimeZone, validation.Required, validation.By(isTimeZone)),
+	)
+}
+
+// Validate validates the batch configuration.
+func (b BatchConfig) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.MaxWorkers, validation.Required, validation.Min(1)),
+		validation.Field(&b.MaxItems, validation.Required, validation.Min(1)),
+	)
+}
+
+func isTimeZone(value interface{}) error {
+	name, _ := value.(string)
+	if _, err := time.LoadLocation(name); err != nil {
+		return errors.New("must be a valid IANA time zone")
+	}
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -69,6 +126,24 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		return strings.Split(value, ",")
@@ -1,15 +1,24 @@
 package di
 
 import (
+	"time"
+
 	"github.com/samber/do/v2"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/handler/pricing"
+	agreementrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/agreement"
+	budgetrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/budget"
+	catalogrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/catalog"
 	estimationrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/estimation"
 	pricingrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/pricing"
+	taxrepo "github.com/c18t-com/clever-pricing-calculator/backend/internal/adapter/repository/tax"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/command"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/query"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/config"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/repository"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/service"
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/infrastructure/worker"
 )
 
 // NewContainer creates a new dependency injection container with all services registered.
@@ -20,49 +29,331 @@ func NewContainer(cfg *config.Config) *do.RootScope {
 	do.ProvideValue(injector, cfg)
 
 	// Register repositories
-	do.Provide(injector, func(i do.Injector) (repository.PricingRepository, error) {
+	do.Provide(injector, func(i do.Injector) (*pricingrepo.CleverCloudRepository, error) {
 		cfg := do.MustInvoke[*config.Config](i)
 		return pricingrepo.NewCleverCloudRepository(&cfg.CleverCloud), nil
 	})
 
+	do.Provide(injector, func(i do.Injector) (*pricingrepo.SnapshotRepository, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		return pricingrepo.LoadSnapshotRepository(&cfg.Catalog)
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.PricingRepository, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		if cfg.Catalog.Source == config.CatalogSourceSnapshot {
+			return do.Invoke[*pricingrepo.SnapshotRepository](i)
+		}
+
+		var pricingRepo repository.PricingRepository = do.MustInvoke[*pricingrepo.CleverCloudRepository](i)
+		if cfg.Catalog.CacheTTL > 0 {
+			pricingRepo = pricingrepo.NewCachedRepository(pricingRepo, &cfg.Catalog)
+		}
+		return pricingRepo, nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.AddonCatalogRepository, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		if cfg.Catalog.Source == config.CatalogSourceSnapshot {
+			return do.Invoke[*pricingrepo.SnapshotRepository](i)
+		}
+
+		var addonRepo repository.AddonCatalogRepository = do.MustInvoke[*pricingrepo.CleverCloudRepository](i)
+		if cfg.Catalog.CacheTTL > 0 {
+			addonRepo = pricingrepo.NewCachedAddonCatalogRepository(addonRepo, &cfg.Catalog)
+		}
+		return addonRepo, nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.ZoneRepository, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		if cfg.Catalog.Source == config.CatalogSourceSnapshot {
+			return do.Invoke[*pricingrepo.SnapshotRepository](i)
+		}
+
+		var zoneRepo repository.ZoneRepository = do.MustInvoke[*pricingrepo.CleverCloudRepository](i)
+		if cfg.Catalog.CacheTTL > 0 {
+			zoneRepo = pricingrepo.NewCachedZoneRepository(zoneRepo, &cfg.Catalog)
+		}
+		return zoneRepo, nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.UsagePricingRepository, error) {
+		return pricingrepo.NewUsagePricingRegistry(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.CatalogHistoryRepository, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		if cfg.Catalog.HistoryDir != "" {
+			return catalogrepo.NewFileRepository(cfg.Catalog.HistoryDir)
+		}
+		return catalogrepo.NewMemoryRepository(), nil
+	})
+
 	do.Provide(injector, func(i do.Injector) (repository.EstimationRepository, error) {
 		return estimationrepo.NewMemoryRepository(), nil
 	})
 
+	do.Provide(injector, func(i do.Injector) (repository.BudgetRepository, error) {
+		return budgetrepo.NewMemoryRepository(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.TaxProfileRepository, error) {
+		return taxrepo.NewMemoryRepository(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.VATRateRepository, error) {
+		return taxrepo.NewVATRateTable(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (repository.PricingAgreementRepository, error) {
+		return agreementrepo.NewMemoryRepository(), nil
+	})
+
+	// Register domain services
+	do.Provide(injector, func(i do.Injector) (*service.ScalingSimulator, error) {
+		return service.NewScalingSimulator(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*service.OptimizationAdvisor, error) {
+		return service.NewOptimizationAdvisor(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*service.CostSimulator, error) {
+		return service.NewCostSimulator(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*service.CostProjector, error) {
+		return service.NewCostProjector(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*service.BudgetEvaluator, error) {
+		return service.NewBudgetEvaluator(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*service.TaxCalculator, error) {
+		return service.NewTaxCalculator(), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*entity.BillingCalendar, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		location, err := time.LoadLocation(cfg.Billing.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		return entity.NewBillingCalendar(entity.BillingCalendarKind(cfg.Billing.Calendar), location)
+	})
+
 	// Register query handlers
 	do.Provide(injector, func(i do.Injector) (*query.ListInstancesHandler, error) {
 		pricingRepo := do.MustInvoke[repository.PricingRepository](i)
 		return query.NewListInstancesHandler(pricingRepo), nil
 	})
 
+	do.Provide(injector, func(i do.Injector) (*query.ListAddonProvidersHandler, error) {
+		addonRepo := do.MustInvoke[repository.AddonCatalogRepository](i)
+		return query.NewListAddonProvidersHandler(addonRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.ListZonesHandler, error) {
+		zoneRepo := do.MustInvoke[repository.ZoneRepository](i)
+		return query.NewListZonesHandler(zoneRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.ExportCatalogHandler, error) {
+		pricingRepo := do.MustInvoke[repository.PricingRepository](i)
+		addonRepo := do.MustInvoke[repository.AddonCatalogRepository](i)
+		zoneRepo := do.MustInvoke[repository.ZoneRepository](i)
+		return query.NewExportCatalogHandler(pricingRepo, addonRepo, zoneRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.ListCatalogSnapshotsHandler, error) {
+		historyRepo := do.MustInvoke[repository.CatalogHistoryRepository](i)
+		return query.NewListCatalogSnapshotsHandler(historyRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.DiffCatalogHandler, error) {
+		historyRepo := do.MustInvoke[repository.CatalogHistoryRepository](i)
+		return query.NewDiffCatalogHandler(historyRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.SimulateScalingHandler, error) {
+		pricingRepo := do.MustInvoke[repository.PricingRepository](i)
+		scalingSimulator := do.MustInvoke[*service.ScalingSimulator](i)
+		return query.NewSimulateScalingHandler(pricingRepo, scalingSimulator), nil
+	})
+
 	do.Provide(injector, func(i do.Injector) (*query.GetEstimationHandler, error) {
 		estimationRepo := do.MustInvoke[repository.EstimationRepository](i)
 		return query.NewGetEstimationHandler(estimationRepo), nil
 	})
 
+	do.Provide(injector, func(i do.Injector) (*query.ProjectCostHandler, error) {
+		estimationRepo := do.MustInvoke[repository.EstimationRepository](i)
+		costProjector := do.MustInvoke[*service.CostProjector](i)
+		calendar := do.MustInvoke[*entity.BillingCalendar](i)
+		return query.NewProjectCostHandler(estimationRepo, costProjector, calendar), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.EvaluateBudgetHandler, error) {
+		budgetRepo := do.MustInvoke[repository.BudgetRepository](i)
+		estimationRepo := do.MustInvoke[repository.EstimationRepository](i)
+		budgetEvaluator := do.MustInvoke[*service.BudgetEvaluator](i)
+		return query.NewEvaluateBudgetHandler(budgetRepo, estimationRepo, budgetEvaluator), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.GetTaxProfileHandler, error) {
+		taxProfileRepo := do.MustInvoke[repository.TaxProfileRepository](i)
+		return query.NewGetTaxProfileHandler(taxProfileRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.GetPricingAgreementHandler, error) {
+		agreementRepo := do.MustInvoke[repository.PricingAgreementRepository](i)
+		return query.NewGetPricingAgreementHandler(agreementRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*query.ListBudgetAlertsHandler, error) {
+		budgetRepo := do.MustInvoke[repository.BudgetRepository](i)
+		return query.NewListBudgetAlertsHandler(budgetRepo), nil
+	})
+
 	// Register command handlers
 	do.Provide(injector, func(i do.Injector) (*command.CalculateCostHandler, error) {
 		pricingRepo := do.MustInvoke[repository.PricingRepository](i)
-		return command.NewCalculateCostHandler(pricingRepo), nil
+		addonRepo := do.MustInvoke[repository.AddonCatalogRepository](i)
+		usagePricingRepo := do.MustInvoke[repository.UsagePricingRepository](i)
+		scalingSimulator := do.MustInvoke[*service.ScalingSimulator](i)
+		calendar := do.MustInvoke[*entity.BillingCalendar](i)
+		taxProfileRepo := do.MustInvoke[repository.TaxProfileRepository](i)
+		vatRateRepo := do.MustInvoke[repository.VATRateRepository](i)
+		taxCalculator := do.MustInvoke[*service.TaxCalculator](i)
+		agreementRepo := do.MustInvoke[repository.PricingAgreementRepository](i)
+		return command.NewCalculateCostHandler(pricingRepo, addonRepo, usagePricingRepo, scalingSimulator, calendar, taxProfileRepo, vatRateRepo, taxCalculator, agreementRepo), nil
 	})
 
 	do.Provide(injector, func(i do.Injector) (*command.SaveEstimationHandler, error) {
 		estimationRepo := do.MustInvoke[repository.EstimationRepository](i)
-		return command.NewSaveEstimationHandler(estimationRepo), nil
+		historyRepo := do.MustInvoke[repository.CatalogHistoryRepository](i)
+		budgetRepo := do.MustInvoke[repository.BudgetRepository](i)
+		budgetEvaluator := do.MustInvoke[*service.BudgetEvaluator](i)
+		return command.NewSaveEstimationHandler(estimationRepo, historyRepo, budgetRepo, budgetEvaluator), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.RecomputeEstimationHandler, error) {
+		estimationRepo := do.MustInvoke[repository.EstimationRepository](i)
+		historyRepo := do.MustInvoke[repository.CatalogHistoryRepository](i)
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		return command.NewRecomputeEstimationHandler(estimationRepo, historyRepo, calculateCostHandler), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.SimulateCostHandler, error) {
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		costSimulator := do.MustInvoke[*service.CostSimulator](i)
+		return command.NewSimulateCostHandler(calculateCostHandler, costSimulator), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.ComputeCostHeatmapHandler, error) {
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		return command.NewComputeCostHeatmapHandler(calculateCostHandler), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.CalculateCostBatchHandler, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		return command.NewCalculateCostBatchHandler(calculateCostHandler, cfg.Batch.MaxWorkers, cfg.Batch.MaxItems), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.RecalculationSessionHandler, error) {
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		return command.NewRecalculationSessionHandler(calculateCostHandler), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.SuggestOptimizationsHandler, error) {
+		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
+		optimizationAdvisor := do.MustInvoke[*service.OptimizationAdvisor](i)
+		return command.NewSuggestOptimizationsHandler(calculateCostHandler, optimizationAdvisor), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.SetBudgetHandler, error) {
+		budgetRepo := do.MustInvoke[repository.BudgetRepository](i)
+		return command.NewSetBudgetHandler(budgetRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.SetTaxProfileHandler, error) {
+		taxProfileRepo := do.MustInvoke[repository.TaxProfileRepository](i)
+		return command.NewSetTaxProfileHandler(taxProfileRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.SetPricingAgreementHandler, error) {
+		agreementRepo := do.MustInvoke[repository.PricingAgreementRepository](i)
+		return command.NewSetPricingAgreementHandler(agreementRepo), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*command.RecordCatalogSnapshotHandler, error) {
+		exportCatalogHandler := do.MustInvoke[*query.ExportCatalogHandler](i)
+		historyRepo := do.MustInvoke[repository.CatalogHistoryRepository](i)
+		return command.NewRecordCatalogSnapshotHandler(exportCatalogHandler, historyRepo), nil
+	})
+
+	// Register background workers
+	do.Provide(injector, func(i do.Injector) (*worker.CatalogRecorder, error) {
+		cfg := do.MustInvoke[*config.Config](i)
+		recordHandler := do.MustInvoke[*command.RecordCatalogSnapshotHandler](i)
+		return worker.NewCatalogRecorder(recordHandler, cfg.Catalog.HistoryInterval), nil
 	})
 
 	// Register gRPC-Connect handler
 	do.Provide(injector, func(i do.Injector) (*pricing.Handler, error) {
 		listInstancesHandler := do.MustInvoke[*query.ListInstancesHandler](i)
+		listAddonProvidersHandler := do.MustInvoke[*query.ListAddonProvidersHandler](i)
+		listZonesHandler := do.MustInvoke[*query.ListZonesHandler](i)
+		exportCatalogHandler := do.MustInvoke[*query.ExportCatalogHandler](i)
+		listCatalogSnapshotsHandler := do.MustInvoke[*query.ListCatalogSnapshotsHandler](i)
+		diffCatalogHandler := do.MustInvoke[*query.DiffCatalogHandler](i)
+		simulateScalingHandler := do.MustInvoke[*query.SimulateScalingHandler](i)
 		getEstimationHandler := do.MustInvoke[*query.GetEstimationHandler](i)
 		calculateCostHandler := do.MustInvoke[*command.CalculateCostHandler](i)
 		saveEstimationHandler := do.MustInvoke[*command.SaveEstimationHandler](i)
+		recomputeEstimationHandler := do.MustInvoke[*command.RecomputeEstimationHandler](i)
+		simulateCostHandler := do.MustInvoke[*command.SimulateCostHandler](i)
+		projectCostHandler := do.MustInvoke[*query.ProjectCostHandler](i)
+		computeCostHeatmapHandler := do.MustInvoke[*command.ComputeCostHeatmapHandler](i)
+		evaluateBudgetHandler := do.MustInvoke[*query.EvaluateBudgetHandler](i)
+		listBudgetAlertsHandler := do.MustInvoke[*query.ListBudgetAlertsHandler](i)
+		setBudgetHandler := do.MustInvoke[*command.SetBudgetHandler](i)
+		getTaxProfileHandler := do.MustInvoke[*query.GetTaxProfileHandler](i)
+		setTaxProfileHandler := do.MustInvoke[*command.SetTaxProfileHandler](i)
+		getPricingAgreementHandler := do.MustInvoke[*query.GetPricingAgreementHandler](i)
+		setPricingAgreementHandler := do.MustInvoke[*command.SetPricingAgreementHandler](i)
+		suggestOptimizationsHandler := do.MustInvoke[*command.SuggestOptimizationsHandler](i)
+		calculateCostBatchHandler := do.MustInvoke[*command.CalculateCostBatchHandler](i)
+		recalculationSessionHandler := do.MustInvoke[*command.RecalculationSessionHandler](i)
 
 		return pricing.NewHandler(
 			listInstancesHandler,
+			listAddonProvidersHandler,
+			listZonesHandler,
+			exportCatalogHandler,
+			listCatalogSnapshotsHandler,
+			diffCatalogHandler,
+			simulateScalingHandler,
 			getEstimationHandler,
 			calculateCostHandler,
 			saveEstimationHandler,
+			recomputeEstimationHandler,
+			simulateCostHandler,
+			projectCostHandler,
+			computeCostHeatmapHandler,
+			evaluateBudgetHandler,
+			listBudgetAlertsHandler,
+			setBudgetHandler,
+			getTaxProfileHandler,
+			setTaxProfileHandler,
+			getPricingAgreementHandler,
+			setPricingAgreementHandler,
+			suggestOptimizationsHandler,
+			calculateCostBatchHandler,
+			recalculationSessionHandler,
 		), nil
 	})
 
@@ -0,0 +1,92 @@
+package entity
+
+// AddonProvider represents an addon provider (database, storage, ...) with its plans.
+type AddonProvider struct {
+	ID        string
+	Name      string
+	ShortDesc string
+	LogoURL   string
+	Status    string
+	Regions   []string
+	Plans     []*AddonPlan
+	Features  []*AddonFeature
+}
+
+// AddonPlan represents a plan of an addon provider.
+type AddonPlan struct {
+	ID   string
+	Name string
+	Slug string
+	// Price is the monthly price of the plan.
+	Price    Money
+	PriceID  string
+	Features []*AddonFeature
+	Zones    []string
+}
+
+// AddonFeature represents a feature of an addon provider or plan (memory, disk, ...).
+type AddonFeature struct {
+	Name     string
+	Type     string
+	Value    string
+	NameCode string
+}
+
+// NewAddonProvider creates a new AddonProvider.
+func NewAddonProvider(id, name, status string) *AddonProvider {
+	return &AddonProvider{
+		ID:       id,
+		Name:     name,
+		Status:   status,
+		Regions:  make([]string, 0),
+		Plans:    make([]*AddonPlan, 0),
+		Features: make([]*AddonFeature, 0),
+	}
+}
+
+// NewAddonPlan creates a new AddonPlan.
+func NewAddonPlan(id, name, slug string, price Money) *AddonPlan {
+	return &AddonPlan{
+		ID:       id,
+		Name:     name,
+		Slug:     slug,
+		Price:    price,
+		Features: make([]*AddonFeature, 0),
+		Zones:    make([]string, 0),
+	}
+}
+
+// NewAddonFeature creates a new AddonFeature.
+func NewAddonFeature(name, featureType, value, nameCode string) *AddonFeature {
+	return &AddonFeature{
+		Name:     name,
+		Type:     featureType,
+		Value:    value,
+		NameCode: nameCode,
+	}
+}
+
+// AddPlan adds a plan to the provider.
+func (p *AddonProvider) AddPlan(plan *AddonPlan) {
+	p.Plans = append(p.Plans, plan)
+}
+
+// FindPlanByID finds a plan by its ID.
+func (p *AddonProvider) FindPlanByID(id string) *AddonPlan {
+	for _, plan := range p.Plans {
+		if plan.ID == id {
+			return plan
+		}
+	}
+	return nil
+}
+
+// IsAvailableIn returns true if the plan can be provisioned in the given zone.
+func (p *AddonPlan) IsAvailableIn(zoneID string) bool {
+	for _, z := range p.Zones {
+		if z == zoneID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// BillingCalendarKind selects how many hours a billed month has.
+type BillingCalendarKind string
+
+// Billing calendar kinds.
+const (
+	// BillingCalendarFixed720 bills 30 days of 24 hours, as Clever Cloud does.
+	BillingCalendarFixed720 BillingCalendarKind = "fixed_720"
+	// BillingCalendarFixed730 bills the average month of 365 days / 12.
+	BillingCalendarFixed730 BillingCalendarKind = "fixed_730"
+	// BillingCalendarCalendarMonth bills the actual hours of a calendar month,
+	// including daylight saving time changes.
+	BillingCalendarCalendarMonth BillingCalendarKind = "calendar_month"
+)
+
+// DefaultBillingCalendarKind is the billing calendar used when none is configured.
+const DefaultBillingCalendarKind = BillingCalendarFixed720
+
+// BillingCalendar converts hourly prices to monthly costs.
+type BillingCalendar struct {
+	Kind BillingCalendarKind
+	// Year and Month select the billed month of a calendar-month calendar.
+	Year  int
+	Month time.Month
+	// Location is the time zone calendar months are counted in, UTC when nil.
+	Location *time.Location
+}
+
+// NewBillingCalendar creates a new BillingCalendar of the given kind.
+func NewBillingCalendar(kind BillingCalendarKind, location *time.Location) (*BillingCalendar, error) {
+	switch kind {
+	case BillingCalendarFixed720, BillingCalendarFixed730, BillingCalendarCalendarMonth:
+	default:
+		return nil, fmt.Errorf("unknown billing calendar %q", kind)
+	}
+
+	return &BillingCalendar{
+		Kind:     kind,
+		Location: location,
+	}, nil
+}
+
+// ForMonth returns a copy of the calendar billing the given month.
+func (c *BillingCalendar) ForMonth(year int, month time.Month) *BillingCalendar {
+	calendar := *c
+	calendar.Year = year
+	calendar.Month = month
+	return &calendar
+}
+
+// Resolve returns a copy of the calendar with the billed month set to the month
+// of t when the calendar is a calendar-month one without a month.
+func (c *BillingCalendar) Resolve(t time.Time) *BillingCalendar {
+	if c.Kind != BillingCalendarCalendarMonth || c.Month != 0 {
+		calendar := *c
+		return &calendar
+	}
+
+	t = t.In(c.location())
+	return c.ForMonth(t.Year(), t.Month())
+}
+
+// HoursPerMonth returns the number of billed hours in a month. A calendar-month
+// calendar without a month bills the current month.
+func (c *BillingCalendar) HoursPerMonth() float64 {
+	switch c.Kind {
+	case BillingCalendarFixed730:
+		return 730
+	case BillingCalendarCalendarMonth:
+		resolved := c.Resolve(time.Now())
+		start := time.Date(resolved.Year, resolved.Month, 1, 0, 0, 0, 0, c.location())
+		return start.AddDate(0, 1, 0).Sub(start).Hours()
+	default:
+		return 720
+	}
+}
+
+// WeeksPerMonth returns the number of weeks in a billed month, used to turn the
+// cost of a weekly schedule into a monthly cost.
+func (c *BillingCalendar) WeeksPerMonth() float64 {
+	return c.HoursPerMonth() / HoursPerWeek
+}
+
+// MonthlyCost returns the monthly cost of an hourly cost running all month.
+func (c *BillingCalendar) MonthlyCost(hourly Money) Money {
+	return hourly.Times(c.wholeHoursPerMonth())
+}
+
+// MonthlyCostOfWeek returns the monthly cost of a weekly cost, exactly
+// prorated on the hours of the month.
+func (c *BillingCalendar) MonthlyCostOfWeek(weekly Money) Money {
+	return weekly.MulRatio(c.wholeHoursPerMonth(), HoursPerWeek)
+}
+
+// Prorate converts a monthly cost computed with another calendar to the hours
+// of the month of c, exactly.
+func (c *BillingCalendar) Prorate(monthly Money, from *BillingCalendar) Money {
+	return monthly.MulRatio(c.wholeHoursPerMonth(), from.wholeHoursPerMonth())
+}
+
+// BilledHoursPerMonth returns the whole number of hours monthly costs are
+// computed with, HoursPerMonth rounded.
+func (c *BillingCalendar) BilledHoursPerMonth() int64 {
+	return c.wholeHoursPerMonth()
+}
+
+func (c *BillingCalendar) wholeHoursPerMonth() int64 {
+	return int64(math.Round(c.HoursPerMonth()))
+}
+
+func (c *BillingCalendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
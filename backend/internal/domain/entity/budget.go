@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default budget thresholds, as ratios of the monthly limit.
+const (
+	DefaultBudgetWarningThreshold  = 0.8
+	DefaultBudgetCriticalThreshold = 1.0
+)
+
+// BudgetStatus represents how close a cost is to its budget.
+type BudgetStatus string
+
+// Budget statuses, by increasing severity.
+const (
+	BudgetStatusOK       BudgetStatus = "ok"
+	BudgetStatusWarning  BudgetStatus = "warning"
+	BudgetStatusCritical BudgetStatus = "critical"
+)
+
+// Severity returns the rank of the status, 0 for BudgetStatusOK.
+func (s BudgetStatus) Severity() int {
+	switch s {
+	case BudgetStatusCritical:
+		return 2
+	case BudgetStatusWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Budget represents the monthly budget of an organization, or of one of its
+// projects when ProjectID is set.
+type Budget struct {
+	OrganizationID string
+	ProjectID      string
+	MonthlyLimit   Money
+	// WarningThreshold and CriticalThreshold are ratios of MonthlyLimit.
+	WarningThreshold  float64
+	CriticalThreshold float64
+	UpdatedAt         time.Time
+}
+
+// NewBudget creates a new Budget. Zero thresholds take their default value.
+func NewBudget(organizationID, projectID string, monthlyLimit Money, warningThreshold, criticalThreshold float64) (*Budget, error) {
+	if organizationID == "" {
+		return nil, errors.New("organization ID is required")
+	}
+	if monthlyLimit.IsNegative() || monthlyLimit.IsZero() {
+		return nil, errors.New("monthly limit must be positive")
+	}
+
+	if warningThreshold == 0 {
+		warningThreshold = DefaultBudgetWarningThreshold
+	}
+	if criticalThreshold == 0 {
+		criticalThreshold = DefaultBudgetCriticalThreshold
+	}
+	if warningThreshold < 0 || warningThreshold > criticalThreshold {
+		return nil, errors.New("warning threshold must be positive and at most the critical threshold")
+	}
+
+	return &Budget{
+		OrganizationID:    organizationID,
+		ProjectID:         projectID,
+		MonthlyLimit:      monthlyLimit,
+		WarningThreshold:  warningThreshold,
+		CriticalThreshold: criticalThreshold,
+		UpdatedAt:         time.Now(),
+	}, nil
+}
+
+// IsProjectBudget returns true if the budget applies to a single project.
+func (b *Budget) IsProjectBudget() bool {
+	return b.ProjectID != ""
+}
+
+// Status returns the status of a monthly cost against the budget.
+func (b *Budget) Status(cost Money) BudgetStatus {
+	utilization := cost.Ratio(b.MonthlyLimit)
+	switch {
+	case utilization >= b.CriticalThreshold:
+		return BudgetStatusCritical
+	case utilization >= b.WarningThreshold:
+		return BudgetStatusWarning
+	default:
+		return BudgetStatusOK
+	}
+}
+
+// Threshold returns the threshold ratio of a status, 0 for BudgetStatusOK.
+func (b *Budget) Threshold(status BudgetStatus) float64 {
+	switch status {
+	case BudgetStatusCritical:
+		return b.CriticalThreshold
+	case BudgetStatusWarning:
+		return b.WarningThreshold
+	default:
+		return 0
+	}
+}
+
+// Evaluate returns the evaluation of a monthly cost against the budget.
+func (b *Budget) Evaluate(cost Money) *BudgetFigure {
+	return &BudgetFigure{
+		Cost:        cost,
+		Headroom:    b.MonthlyLimit.Sub(cost),
+		Utilization: cost.Ratio(b.MonthlyLimit),
+		Status:      b.Status(cost),
+	}
+}
+
+// BudgetEvaluation represents the monthly costs of an organization or project
+// against its budget.
+type BudgetEvaluation struct {
+	Budget *Budget
+	// EstimationIDs are the estimations the costs were summed from: the latest
+	// saved estimation of each project.
+	EstimationIDs []string
+	Min           *BudgetFigure
+	Expected      *BudgetFigure
+	Max           *BudgetFigure
+}
+
+// BudgetFigure represents a monthly cost against a budget.
+type BudgetFigure struct {
+	Cost Money
+	// Headroom is the monthly limit minus the cost, negative over budget.
+	Headroom Money
+	// Utilization is the cost as a ratio of the monthly limit.
+	Utilization float64
+	Status      BudgetStatus
+}
+
+// BudgetAlert records a saved estimation pushing the expected cost of a
+// budget over one of its thresholds.
+type BudgetAlert struct {
+	ID             string
+	OrganizationID string
+	// ProjectID is the project of a project budget, empty for the organization budget.
+	ProjectID    string
+	EstimationID string
+	Status       BudgetStatus
+	Threshold    float64
+	ExpectedCost Money
+	MonthlyLimit Money
+	CreatedAt    time.Time
+}
+
+// NewBudgetAlert creates a new BudgetAlert with a generated ID for an
+// evaluation of a budget after saving an estimation.
+func NewBudgetAlert(evaluation *BudgetEvaluation, estimationID string) *BudgetAlert {
+	budget := evaluation.Budget
+	return &BudgetAlert{
+		ID:             uuid.New().String(),
+		OrganizationID: budget.OrganizationID,
+		ProjectID:      budget.ProjectID,
+		EstimationID:   estimationID,
+		Status:         evaluation.Expected.Status,
+		Threshold:      budget.Threshold(evaluation.Expected.Status),
+		ExpectedCost:   evaluation.Expected.Cost,
+		MonthlyLimit:   budget.MonthlyLimit,
+		CreatedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"time"
+)
+
+// CatalogSnapshot is a point-in-time copy of the whole pricing catalog:
+// zones, instances with their flavors per zone, and addon providers with their plans.
+type CatalogSnapshot struct {
+	ID             string
+	GeneratedAt    time.Time
+	Zones          []*Zone
+	Instances      map[string][]*Instance
+	AddonProviders []*AddonProvider
+}
+
+// NewCatalogSnapshot creates a new empty CatalogSnapshot.
+func NewCatalogSnapshot(generatedAt time.Time) *CatalogSnapshot {
+	return &CatalogSnapshot{
+		GeneratedAt:    generatedAt,
+		Zones:          make([]*Zone, 0),
+		Instances:      make(map[string][]*Instance),
+		AddonProviders: make([]*AddonProvider, 0),
+	}
+}
+
+// Checksum returns a hash of the priced content of the snapshot: zones, instances,
+// flavors and addon plans. It ignores the ID, the generation time and the order
+// in which the API returned items, so two snapshots with the same checksum price
+// everything the same way.
+func (s *CatalogSnapshot) Checksum() string {
+	h := sha256.New()
+
+	zones := append([]*Zone(nil), s.Zones...)
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	for _, z := range zones {
+		writeFields(h, "zone", z.Name, z.CountryCode)
+	}
+
+	zoneIDs := make([]string, 0, len(s.Instances))
+	for zoneID := range s.Instances {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Strings(zoneIDs)
+	for _, zoneID := range zoneIDs {
+		instances := append([]*Instance(nil), s.Instances[zoneID]...)
+		sort.Slice(instances, func(i, j int) bool { return instances[i].Type < instances[j].Type })
+		for _, inst := range instances {
+			writeFields(h, "instance", zoneID, inst.Type, inst.Version)
+			flavors := append([]*Flavor(nil), inst.Flavors...)
+			sort.Slice(flavors, func(i, j int) bool { return flavors[i].Name < flavors[j].Name })
+			for _, f := range flavors {
+				writeFields(h, "flavor", f.Name, f.Mem, f.CPUs, f.PricePerHour.Float64(), f.Available)
+			}
+		}
+	}
+
+	providers := append([]*AddonProvider(nil), s.AddonProviders...)
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ID < providers[j].ID })
+	for _, p := range providers {
+		writeFields(h, "provider", p.ID)
+		plans := append([]*AddonPlan(nil), p.Plans...)
+		sort.Slice(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+		for _, pl := range plans {
+			zones := append([]string(nil), pl.Zones...)
+			sort.Strings(zones)
+			writeFields(h, "plan", pl.ID, pl.Price.Float64(), zones)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FindInstance returns the instance of the given type in a zone, or nil.
+func (s *CatalogSnapshot) FindInstance(zoneID, instanceType string) *Instance {
+	for _, inst := range s.Instances[zoneID] {
+		if inst.Type == instanceType {
+			return inst
+		}
+	}
+	return nil
+}
+
+func writeFields(h hash.Hash, fields ...any) {
+	for _, f := range fields {
+		fmt.Fprintf(h, "%v\x1f", f)
+	}
+	h.Write([]byte{'\n'})
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// CatalogDiff represents the changes between two catalog snapshots.
+type CatalogDiff struct {
+	FromID           string
+	ToID             string
+	FromGeneratedAt  time.Time
+	ToGeneratedAt    time.Time
+	AddedInstances   []*InstanceRef
+	RemovedInstances []*InstanceRef
+	AddedFlavors     []*FlavorRef
+	RemovedFlavors   []*FlavorRef
+	PriceChanges     []*FlavorPriceChange
+}
+
+// InstanceRef identifies an instance type in a zone.
+type InstanceRef struct {
+	ZoneID       string
+	InstanceType string
+}
+
+// FlavorRef identifies a flavor of an instance type in a zone.
+type FlavorRef struct {
+	ZoneID       string
+	InstanceType string
+	FlavorName   string
+}
+
+// FlavorPriceChange represents the hourly price change of a flavor present in both snapshots.
+type FlavorPriceChange struct {
+	FlavorRef
+	OldPricePerHour Money
+	NewPricePerHour Money
+}
+
+// Delta returns the hourly price difference.
+func (c *FlavorPriceChange) Delta() Money {
+	return c.NewPricePerHour.Sub(c.OldPricePerHour)
+}
+
+// DeltaPercent returns the relative hourly price difference in percent,
+// or 0 when the old price was 0.
+func (c *FlavorPriceChange) DeltaPercent() float64 {
+	return c.Delta().Ratio(c.OldPricePerHour) * 100
+}
+
+// IsEmpty returns true if the diff contains no change.
+func (d *CatalogDiff) IsEmpty() bool {
+	return len(d.AddedInstances) == 0 && len(d.RemovedInstances) == 0 &&
+		len(d.AddedFlavors) == 0 && len(d.RemovedFlavors) == 0 &&
+		len(d.PriceChanges) == 0
+}
+
+// DiffCatalogSnapshots compares the instances and flavors of two snapshots, zone by zone.
+func DiffCatalogSnapshots(from, to *CatalogSnapshot) *CatalogDiff {
+	diff := &CatalogDiff{
+		FromID:           from.ID,
+		ToID:             to.ID,
+		FromGeneratedAt:  from.GeneratedAt,
+		ToGeneratedAt:    to.GeneratedAt,
+		AddedInstances:   make([]*InstanceRef, 0),
+		RemovedInstances: make([]*InstanceRef, 0),
+		AddedFlavors:     make([]*FlavorRef, 0),
+		RemovedFlavors:   make([]*FlavorRef, 0),
+		PriceChanges:     make([]*FlavorPriceChange, 0),
+	}
+
+	for _, zoneID := range unionZoneIDs(from, to) {
+		for _, oldInst := range from.Instances[zoneID] {
+			newInst := to.FindInstance(zoneID, oldInst.Type)
+			if newInst == nil {
+				diff.RemovedInstances = append(diff.RemovedInstances, &InstanceRef{ZoneID: zoneID, InstanceType: oldInst.Type})
+				continue
+			}
+			diff.diffFlavors(zoneID, oldInst, newInst)
+		}
+
+		for _, newInst := range to.Instances[zoneID] {
+			if from.FindInstance(zoneID, newInst.Type) == nil {
+				diff.AddedInstances = append(diff.AddedInstances, &InstanceRef{ZoneID: zoneID, InstanceType: newInst.Type})
+			}
+		}
+	}
+
+	return diff
+}
+
+func (d *CatalogDiff) diffFlavors(zoneID string, oldInst, newInst *Instance) {
+	for _, oldFlavor := range oldInst.Flavors {
+		ref := FlavorRef{ZoneID: zoneID, InstanceType: oldInst.Type, FlavorName: oldFlavor.Name}
+		newFlavor := newInst.FindFlavorByName(oldFlavor.Name)
+		if newFlavor == nil {
+			d.RemovedFlavors = append(d.RemovedFlavors, &ref)
+			continue
+		}
+		if newFlavor.PricePerHour.Cmp(oldFlavor.PricePerHour) != 0 {
+			d.PriceChanges = append(d.PriceChanges, &FlavorPriceChange{
+				FlavorRef:       ref,
+				OldPricePerHour: oldFlavor.PricePerHour,
+				NewPricePerHour: newFlavor.PricePerHour,
+			})
+		}
+	}
+
+	for _, newFlavor := range newInst.Flavors {
+		if oldInst.FindFlavorByName(newFlavor.Name) == nil {
+			d.AddedFlavors = append(d.AddedFlavors, &FlavorRef{ZoneID: zoneID, InstanceType: newInst.Type, FlavorName: newFlavor.Name})
+		}
+	}
+}
+
+func unionZoneIDs(a, b *CatalogSnapshot) []string {
+	seen := make(map[string]bool)
+	zoneIDs := make([]string, 0, len(a.Instances))
+	for _, s := range []*CatalogSnapshot{a, b} {
+		for zoneID := range s.Instances {
+			if !seen[zoneID] {
+				seen[zoneID] = true
+				zoneIDs = append(zoneIDs, zoneID)
+			}
+		}
+	}
+	sort.Strings(zoneIDs)
+	return zoneIDs
+}
@@ -0,0 +1,29 @@
+package entity
+
+// CostHeatmap represents where the cost of a project goes over the hours of
+// the week. Hours are indexed like WeeklySchedule.
+type CostHeatmap struct {
+	// Runtimes are the heatmaps of the runtimes, in the order of their specs.
+	Runtimes []*RuntimeCostHeatmap
+	// HourlyCosts are the hourly costs of all runtimes.
+	HourlyCosts [DaysPerWeek][HoursPerDay]Money
+	WeeklyCost  Money
+}
+
+// RuntimeCostHeatmap represents the configuration and hourly cost of a runtime
+// at every hour of the week.
+type RuntimeCostHeatmap struct {
+	InstanceType string
+	Hours        [DaysPerWeek][HoursPerDay]*CostHeatmapCell
+	WeeklyCost   Money
+}
+
+// CostHeatmapCell represents a runtime during one hour of the week.
+type CostHeatmapCell struct {
+	// ProfileID is the scaling profile of the hour, empty on the baseline.
+	ProfileID  string
+	LoadLevel  int32
+	FlavorName string
+	Instances  int32
+	HourlyCost Money
+}
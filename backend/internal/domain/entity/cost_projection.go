@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// MonthsPerYear is the number of seasonal multipliers of a cost projection.
+const MonthsPerYear = 12
+
+// CostProjection represents the monthly costs of an estimation over several
+// months of traffic growth and seasonality.
+type CostProjection struct {
+	EstimationID      string
+	MonthlyGrowthRate float64
+	Months            []*MonthlyCostProjection
+	// Totals are the sums of the monthly costs over the horizon.
+	TotalMinCost      Money
+	TotalExpectedCost Money
+	TotalMaxCost      Money
+}
+
+// MonthlyCostProjection represents the projected cost of one calendar month.
+type MonthlyCostProjection struct {
+	Year  int
+	Month time.Month
+	// Hours is the number of hours of the calendar month.
+	Hours float64
+	// TrafficFactor is the traffic of the month relative to the estimation:
+	// the compounded growth times the seasonal multiplier of the month.
+	TrafficFactor float64
+	MinCost       Money
+	ExpectedCost  Money
+	MaxCost       Money
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// LoadDistribution represents the probability of each load level of a schedule
+// slot, as relative weights indexed by load level.
+type LoadDistribution [MaxLoadLevel + 1]float64
+
+// WeeklyLoadModel represents the load distribution of every hour of the week,
+// indexed like WeeklySchedule. A nil distribution keeps the scheduled load level.
+type WeeklyLoadModel [DaysPerWeek][HoursPerDay]*LoadDistribution
+
+// Validate checks that the weights are finite, non-negative and not all zero.
+func (d *LoadDistribution) Validate() error {
+	total := 0.0
+	for level, w := range d {
+		if math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("weight of load level %d must be finite", level)
+		}
+		if w < 0 {
+			return fmt.Errorf("weight of load level %d must not be negative", level)
+		}
+		total += w
+	}
+	if math.IsInf(total, 0) {
+		return errors.New("load distribution weights must have a finite sum")
+	}
+	if total == 0 {
+		return errors.New("load distribution must have a positive weight")
+	}
+	return nil
+}
+
+// Validate checks the load distribution of every hour of the week.
+func (m *WeeklyLoadModel) Validate() error {
+	for day := range m {
+		for hour, d := range m[day] {
+			if d == nil {
+				continue
+			}
+			if err := d.Validate(); err != nil {
+				return validation.NewError("validation_invalid_load_distribution", fmt.Sprintf("load distribution of day %d hour %d: %v", day, hour, err))
+			}
+		}
+	}
+	return nil
+}
+
+// CostSimulation represents the distribution of the monthly cost of a project
+// over Monte Carlo simulations of its load.
+type CostSimulation struct {
+	Iterations int
+	Seed       int64
+	Mean       Money
+	Min        Money
+	Max        Money
+	P50        Money
+	P90        Money
+	P99        Money
+	Histogram  []*CostHistogramBucket
+}
+
+// CostHistogramBucket represents the number of simulated monthly costs within
+// [LowerBound, UpperBound), the last bucket including its upper bound.
+type CostHistogramBucket struct {
+	LowerBound Money
+	UpperBound Money
+	Count      int
+}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CostTrace explains how the figures of an estimation were derived, line by
+// line, so that each of them can be justified.
+type CostTrace struct {
+	// HoursPerMonth is the whole number of hours monthly costs are computed with.
+	HoursPerMonth int64
+	Runtimes      []*RuntimeCostTrace
+	Addons        []*AddonCostTrace
+	// TraceSteps derive the totals of the estimation from its lines.
+	TraceSteps
+}
+
+// TraceSteps lists the computations of a trace in order.
+type TraceSteps struct {
+	Steps []*TraceStep
+}
+
+// TraceStep represents one computation of a trace.
+type TraceStep struct {
+	// Figure is the field of the estimation or line set by the step, like
+	// "estimated_cost", empty for intermediate results.
+	Figure      string
+	Description string
+	// Formula shows the computation with its operands, like "2 × 0.05 EUR".
+	Formula string
+	Amount  Money
+}
+
+// RuntimeCostTrace explains the costs of a runtime line.
+type RuntimeCostTrace struct {
+	RuntimeID string
+	// UnitPrices are the hourly prices of the flavors the runtime runs.
+	UnitPrices []*UnitPriceTrace
+	// LoadLevels groups the hours of the weekly schedule that run the same
+	// configuration, empty without scaling.
+	LoadLevels []*LoadLevelTrace
+	TraceSteps
+}
+
+// UnitPriceTrace represents the hourly price of a flavor.
+type UnitPriceTrace struct {
+	FlavorName   string
+	PricePerHour Money
+	// ListPricePerHour is the catalog price, which differs from PricePerHour
+	// when the pricing agreement overrides it.
+	ListPricePerHour Money
+}
+
+// LoadLevelTrace represents the hours of the weekly schedule set to the same
+// profile and load level, and the configuration they resolve to.
+type LoadLevelTrace struct {
+	// ScheduledProfileID and ScheduledLoadLevel are the profile and load
+	// level set on the hours.
+	ScheduledProfileID string
+	ScheduledLoadLevel int32
+	// ProfileID is the profile the hours run: the scheduled one when enabled,
+	// the default profile otherwise, or empty for the baseline.
+	ProfileID    string
+	LoadLevel    int32
+	FlavorName   string
+	Instances    int32
+	HourlyCost   Money
+	HoursPerWeek int32
+	WeeklyCost   Money
+}
+
+// AddonCostTrace explains the cost of an addon line.
+type AddonCostTrace struct {
+	AddonID string
+	// Usage details the usage costs by metric and tier.
+	Usage []*UsageMetricCost
+	TraceSteps
+}
+
+// AddStep appends a step to the trace.
+func (s *TraceSteps) AddStep(figure, description, formula string, amount Money) {
+	s.Steps = append(s.Steps, &TraceStep{
+		Figure:      figure,
+		Description: description,
+		Formula:     formula,
+		Amount:      amount,
+	})
+}
+
+// Text renders the trace as text, one line per price, load level and step.
+func (t *CostTrace) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Monthly costs computed over %d h\n", t.HoursPerMonth)
+
+	for _, rt := range t.Runtimes {
+		fmt.Fprintf(&b, "\nRuntime %s\n", rt.RuntimeID)
+		for _, p := range rt.UnitPrices {
+			fmt.Fprintf(&b, "  %s: %s/h", p.FlavorName, p.PricePerHour)
+			if p.PricePerHour.Cmp(p.ListPricePerHour) != 0 {
+				fmt.Fprintf(&b, " (catalog %s/h)", p.ListPricePerHour)
+			}
+			b.WriteString("\n")
+		}
+		for _, l := range rt.LoadLevels {
+			fmt.Fprintf(&b, "  %d h/week %s: %d × %s = %s/h, %s/week\n",
+				l.HoursPerWeek, l.resolution(), l.Instances, l.FlavorName, l.HourlyCost, l.WeeklyCost)
+		}
+		writeSteps(&b, rt.Steps)
+	}
+
+	for _, at := range t.Addons {
+		fmt.Fprintf(&b, "\nAddon %s\n", at.AddonID)
+		for _, u := range at.Usage {
+			fmt.Fprintf(&b, "  %s: %.9g %s, %.9g free\n", u.MetricName, u.Value, u.Unit, u.FreeQuotaApplied)
+			for _, tc := range u.Tiers {
+				fmt.Fprintf(&b, "    %.9g %s × %s = %s\n", tc.Quantity, u.Unit, tc.Tier.PricePerUnit, tc.Cost)
+			}
+		}
+		writeSteps(&b, at.Steps)
+	}
+
+	b.WriteString("\nTotals\n")
+	writeSteps(&b, t.Steps)
+	return b.String()
+}
+
+// resolution describes the profile and load level the hours were set to and
+// what they run.
+func (l *LoadLevelTrace) resolution() string {
+	if l.ProfileID == "" {
+		return "baseline"
+	}
+	scheduled := fmt.Sprintf("profile %s level %d", l.ScheduledProfileID, l.ScheduledLoadLevel)
+	if l.ScheduledProfileID == "" {
+		scheduled = fmt.Sprintf("baseline level %d", l.ScheduledLoadLevel)
+	}
+	resolved := fmt.Sprintf("profile %s level %d", l.ProfileID, l.LoadLevel)
+	if scheduled == resolved {
+		return resolved
+	}
+	return scheduled + " → " + resolved
+}
+
+func writeSteps(b *strings.Builder, steps []*TraceStep) {
+	for _, s := range steps {
+		b.WriteString("  ")
+		if s.Figure != "" {
+			fmt.Fprintf(b, "[%s] ", s.Figure)
+		}
+		b.WriteString(s.Description)
+		if s.Formula != "" {
+			fmt.Fprintf(b, ": %s", s.Formula)
+		}
+		fmt.Fprintf(b, " = %s\n", s.Amount)
+	}
+}
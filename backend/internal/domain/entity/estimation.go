@@ -1,41 +1,103 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // CostEstimation represents a cost estimation for a project.
 type CostEstimation struct {
-	ID             string
-	ProjectID      string
-	MinMonthlyCost float64
-	MaxMonthlyCost float64
-	RuntimeCosts   []*RuntimeCost
-	AddonCosts     []*AddonCost
+	ID        string
+	ProjectID string
+	// OrganizationID is the organization the project belongs to, for budgets.
+	OrganizationID string
+	MinMonthlyCost Money
+	MaxMonthlyCost Money
+	// EstimatedMonthlyCost is the cost expected from the schedule load levels,
+	// between MinMonthlyCost and MaxMonthlyCost.
+	EstimatedMonthlyCost Money
+	RuntimeCosts         []*RuntimeCost
+	AddonCosts           []*AddonCost
+	// CatalogVersion is the ID of the catalog snapshot the prices were taken from.
+	CatalogVersion string
+	// PricedAt is the time the estimation was computed.
+	PricedAt time.Time
+	// BillingCalendar is the calendar hourly prices were converted to monthly costs with.
+	BillingCalendar *BillingCalendar
+	// Tax holds the taxed totals, nil when the organization has no tax profile.
+	Tax *EstimationTax
+	// Agreement summarizes the pricing agreement of the organization, nil
+	// when it has none. Costs are then priced with the agreement.
+	Agreement *AgreementSummary
+	// Trace explains how the costs were derived, nil unless requested.
+	Trace *CostTrace
 }
 
 // RuntimeCost represents the cost breakdown for a runtime.
 type RuntimeCost struct {
 	RuntimeID string
 	Name      string
-	MinCost   float64
-	MaxCost   float64
+	MinCost   Money
+	MaxCost   Money
+	// BaseCost is the monthly cost of the runtime at rest: the baseline, or the
+	// minimum configuration of the default profile when scaling is enabled.
+	BaseCost Money
+	// ScalingCost is the estimated monthly cost on top of BaseCost from the schedule load levels.
+	ScalingCost Money
+	// EstimatedCost is the monthly cost expected from the schedule load levels,
+	// BaseCost + ScalingCost.
+	EstimatedCost Money
+	// ScalingHours is the number of hours per week above load level 0, detailed
+	// per profile ID in ScalingHoursByProfile.
+	ScalingHours          int32
+	ScalingHoursByProfile map[string]int32
+	// AverageLoadLevel is the average load level of the scaling hours, to one decimal.
+	AverageLoadLevel float64
+
+	// Spec is the normalized spec the cost was computed with.
+	Spec *RuntimeSpec
+	// UnitPrice is the hourly price of one base instance.
+	UnitPrice Money
+	// ListCost is EstimatedCost at catalog prices, before any pricing agreement.
+	ListCost Money
+	// Tax holds the taxed costs, nil when the estimation is not taxed.
+	Tax *CostRangeTax
+	// Trace explains how the costs were derived, nil unless requested.
+	Trace *RuntimeCostTrace
 }
 
 // AddonCost represents the cost for an addon.
 type AddonCost struct {
 	AddonID string
 	Name    string
-	Cost    float64
+	Cost    Money
+
+	// Spec is the spec the cost was computed with.
+	Spec *AddonSpec
+	// UnitPrice is the monthly price of the plan.
+	UnitPrice Money
+	// UsageCost is the part of Cost billed by usage, detailed per metric in UsageCosts.
+	UsageCost  Money
+	UsageCosts []*UsageMetricCost
+	// ListCost is Cost at catalog prices, before any pricing agreement.
+	ListCost Money
+	// Tax holds the taxed cost, nil when the estimation is not taxed.
+	Tax *TaxedAmount
+	// Trace explains how the cost was derived, nil unless requested.
+	Trace *AddonCostTrace
 }
 
 // NewCostEstimation creates a new CostEstimation with a generated ID.
 func NewCostEstimation(projectID string) *CostEstimation {
 	return &CostEstimation{
-		ID:           uuid.New().String(),
-		ProjectID:    projectID,
-		RuntimeCosts: make([]*RuntimeCost, 0),
-		AddonCosts:   make([]*AddonCost, 0),
+		ID:                   uuid.New().String(),
+		ProjectID:            projectID,
+		MinMonthlyCost:       ZeroMoney(DefaultCurrency),
+		MaxMonthlyCost:       ZeroMoney(DefaultCurrency),
+		EstimatedMonthlyCost: ZeroMoney(DefaultCurrency),
+		RuntimeCosts:         make([]*RuntimeCost, 0),
+		AddonCosts:           make([]*AddonCost, 0),
 	}
 }
 
@@ -51,64 +113,81 @@ func (e *CostEstimation) AddAddonCost(cost *AddonCost) {
 	e.recalculateTotals()
 }
 
-// recalculateTotals recalculates the min and max monthly costs.
+// recalculateTotals recalculates the min, estimated and max monthly costs as
+// the sum of the already rounded lines.
 func (e *CostEstimation) recalculateTotals() {
-	e.MinMonthlyCost = 0
-	e.MaxMonthlyCost = 0
-
-	for _, rc := range e.RuntimeCosts {
-		e.MinMonthlyCost += rc.MinCost
-		e.MaxMonthlyCost += rc.MaxCost
-	}
-
-	for _, ac := range e.AddonCosts {
-		e.MinMonthlyCost += ac.Cost
-		e.MaxMonthlyCost += ac.Cost
-	}
+	e.MinMonthlyCost = e.TotalRuntimeMinCost().Add(e.TotalAddonCost())
+	e.MaxMonthlyCost = e.TotalRuntimeMaxCost().Add(e.TotalAddonCost())
+	e.EstimatedMonthlyCost = e.TotalRuntimeEstimatedCost().Add(e.TotalAddonCost())
 }
 
-// NewRuntimeCost creates a new RuntimeCost.
-func NewRuntimeCost(runtimeID, name string, minCost, maxCost float64) *RuntimeCost {
+// NewRuntimeCost creates a new RuntimeCost with its costs rounded to the cent.
+func NewRuntimeCost(runtimeID, name string, minCost, maxCost Money) *RuntimeCost {
 	return &RuntimeCost{
 		RuntimeID: runtimeID,
 		Name:      name,
-		MinCost:   minCost,
-		MaxCost:   maxCost,
+		MinCost:   minCost.RoundToCents(),
+		MaxCost:   maxCost.RoundToCents(),
 	}
 }
 
-// NewAddonCost creates a new AddonCost.
-func NewAddonCost(addonID, name string, cost float64) *AddonCost {
+// NewAddonCost creates a new AddonCost with its cost rounded to the cent.
+func NewAddonCost(addonID, name string, cost Money) *AddonCost {
 	return &AddonCost{
 		AddonID: addonID,
 		Name:    name,
-		Cost:    cost,
+		Cost:    cost.RoundToCents(),
 	}
 }
 
 // TotalRuntimeMinCost returns the total minimum cost of all runtimes.
-func (e *CostEstimation) TotalRuntimeMinCost() float64 {
-	total := 0.0
+func (e *CostEstimation) TotalRuntimeMinCost() Money {
+	total := ZeroMoney(DefaultCurrency)
 	for _, rc := range e.RuntimeCosts {
-		total += rc.MinCost
+		total = total.Add(rc.MinCost)
 	}
 	return total
 }
 
 // TotalRuntimeMaxCost returns the total maximum cost of all runtimes.
-func (e *CostEstimation) TotalRuntimeMaxCost() float64 {
-	total := 0.0
+func (e *CostEstimation) TotalRuntimeMaxCost() Money {
+	total := ZeroMoney(DefaultCurrency)
+	for _, rc := range e.RuntimeCosts {
+		total = total.Add(rc.MaxCost)
+	}
+	return total
+}
+
+// TotalRuntimeEstimatedCost returns the total estimated cost of all runtimes.
+func (e *CostEstimation) TotalRuntimeEstimatedCost() Money {
+	total := ZeroMoney(DefaultCurrency)
 	for _, rc := range e.RuntimeCosts {
-		total += rc.MaxCost
+		total = total.Add(rc.EstimatedCost)
 	}
 	return total
 }
 
 // TotalAddonCost returns the total cost of all addons.
-func (e *CostEstimation) TotalAddonCost() float64 {
-	total := 0.0
+func (e *CostEstimation) TotalAddonCost() Money {
+	total := ZeroMoney(DefaultCurrency)
 	for _, ac := range e.AddonCosts {
-		total += ac.Cost
+		total = total.Add(ac.Cost)
 	}
 	return total
 }
+
+// IsPriceLocked returns true if every line records the spec and unit price
+// it was computed with, so the estimation can be re-priced.
+func (e *CostEstimation) IsPriceLocked() bool {
+	for _, rc := range e.RuntimeCosts {
+		if rc.Spec == nil {
+			return false
+		}
+	}
+	for _, ac := range e.AddonCosts {
+		if ac.Spec == nil {
+			return false
+		}
+	}
+	return true
+}
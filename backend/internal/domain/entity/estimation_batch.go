@@ -0,0 +1,69 @@
+package entity
+
+// CostEstimationBatch represents the estimations of several projects of an
+// organization computed at once.
+type CostEstimationBatch struct {
+	// Items are in the order of the batch, each with an estimation or an error.
+	Items []*CostEstimationBatchItem
+	Total *OrganizationCostTotal
+}
+
+// CostEstimationBatchItem represents the outcome of one project of a batch:
+// its estimation, or the error that prevented it.
+type CostEstimationBatchItem struct {
+	Estimation *CostEstimation
+	Err        error
+}
+
+// OrganizationCostTotal sums the monthly costs of the estimations of a batch.
+type OrganizationCostTotal struct {
+	MinMonthlyCost       Money
+	MaxMonthlyCost       Money
+	EstimatedMonthlyCost Money
+	// Tax sums the taxed totals, nil unless every estimation is taxed.
+	Tax *CostRangeTax
+	// SucceededItems and FailedItems count the items with an estimation and
+	// with an error. Failed items are not part of the total.
+	SucceededItems int
+	FailedItems    int
+}
+
+// NewCostEstimationBatch creates a new CostEstimationBatch from its items,
+// totalling the costs of those with an estimation.
+func NewCostEstimationBatch(items []*CostEstimationBatchItem) *CostEstimationBatch {
+	zero := ZeroMoney(DefaultCurrency)
+	zeroTax := TaxedAmount{Net: zero, Tax: zero, Gross: zero}
+	total := &OrganizationCostTotal{
+		MinMonthlyCost:       zero,
+		MaxMonthlyCost:       zero,
+		EstimatedMonthlyCost: zero,
+		Tax:                  &CostRangeTax{Min: zeroTax, Estimated: zeroTax, Max: zeroTax},
+	}
+
+	for _, item := range items {
+		if item.Estimation == nil {
+			total.FailedItems++
+			continue
+		}
+		total.SucceededItems++
+
+		e := item.Estimation
+		total.MinMonthlyCost = total.MinMonthlyCost.Add(e.MinMonthlyCost)
+		total.MaxMonthlyCost = total.MaxMonthlyCost.Add(e.MaxMonthlyCost)
+		total.EstimatedMonthlyCost = total.EstimatedMonthlyCost.Add(e.EstimatedMonthlyCost)
+		if e.Tax == nil || total.Tax == nil {
+			total.Tax = nil
+			continue
+		}
+		total.Tax = &CostRangeTax{
+			Min:       total.Tax.Min.Add(e.Tax.Min),
+			Estimated: total.Tax.Estimated.Add(e.Tax.Estimated),
+			Max:       total.Tax.Max.Add(e.Tax.Max),
+		}
+	}
+	if total.SucceededItems == 0 {
+		total.Tax = nil
+	}
+
+	return &CostEstimationBatch{Items: items, Total: total}
+}
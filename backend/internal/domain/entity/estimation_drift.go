@@ -0,0 +1,122 @@
+package entity
+
+// EstimationDrift represents the price changes of a saved estimation between
+// the catalog it was priced with and the current catalog.
+type EstimationDrift struct {
+	EstimationID       string
+	FromCatalogVersion string
+	ToCatalogVersion   string
+	// AgreementChanged is true when the pricing agreement of the organization was
+	// updated or removed since the estimation was priced. The unit price and cost
+	// deltas then include the agreement change, the list cost deltas do not.
+	AgreementChanged bool
+	RuntimeDrifts    []*RuntimeCostDrift
+	AddonDrifts      []*AddonCostDrift
+}
+
+// RuntimeCostDrift represents the price change of a runtime line.
+type RuntimeCostDrift struct {
+	RuntimeID    string
+	Name         string
+	OldUnitPrice Money
+	NewUnitPrice Money
+	OldMinCost   Money
+	NewMinCost   Money
+	OldMaxCost   Money
+	NewMaxCost   Money
+	// OldListCost and NewListCost are the estimated costs at catalog prices,
+	// before any pricing agreement.
+	OldListCost Money
+	NewListCost Money
+	// Unavailable is true when the flavor is no longer in the catalog, the new costs are then zero.
+	Unavailable bool
+}
+
+// AddonCostDrift represents the price change of an addon line.
+type AddonCostDrift struct {
+	AddonID      string
+	Name         string
+	OldUnitPrice Money
+	NewUnitPrice Money
+	OldCost      Money
+	NewCost      Money
+	// OldListCost and NewListCost are the costs at catalog prices, before any
+	// pricing agreement.
+	OldListCost Money
+	NewListCost Money
+	// Unavailable is true when the plan is no longer in the catalog, the new cost is then zero.
+	Unavailable bool
+}
+
+// NewEstimationDrift creates a new EstimationDrift for an estimation.
+func NewEstimationDrift(estimationID, fromCatalogVersion, toCatalogVersion string) *EstimationDrift {
+	return &EstimationDrift{
+		EstimationID:       estimationID,
+		FromCatalogVersion: fromCatalogVersion,
+		ToCatalogVersion:   toCatalogVersion,
+		RuntimeDrifts:      make([]*RuntimeCostDrift, 0),
+		AddonDrifts:        make([]*AddonCostDrift, 0),
+	}
+}
+
+// MinCostDelta returns the change of the minimum monthly cost.
+func (d *RuntimeCostDrift) MinCostDelta() Money {
+	return d.NewMinCost.Sub(d.OldMinCost)
+}
+
+// MaxCostDelta returns the change of the maximum monthly cost.
+func (d *RuntimeCostDrift) MaxCostDelta() Money {
+	return d.NewMaxCost.Sub(d.OldMaxCost)
+}
+
+// ListCostDelta returns the change of the estimated monthly cost at catalog prices.
+func (d *RuntimeCostDrift) ListCostDelta() Money {
+	return d.NewListCost.Sub(d.OldListCost)
+}
+
+// CostDelta returns the change of the monthly cost.
+func (d *AddonCostDrift) CostDelta() Money {
+	return d.NewCost.Sub(d.OldCost)
+}
+
+// ListCostDelta returns the change of the monthly cost at catalog prices.
+func (d *AddonCostDrift) ListCostDelta() Money {
+	return d.NewListCost.Sub(d.OldListCost)
+}
+
+// MinMonthlyCostDelta returns the change of the estimation minimum monthly cost.
+func (d *EstimationDrift) MinMonthlyCostDelta() Money {
+	total := ZeroMoney(DefaultCurrency)
+	for _, rd := range d.RuntimeDrifts {
+		total = total.Add(rd.MinCostDelta())
+	}
+	for _, ad := range d.AddonDrifts {
+		total = total.Add(ad.CostDelta())
+	}
+	return total
+}
+
+// MaxMonthlyCostDelta returns the change of the estimation maximum monthly cost.
+func (d *EstimationDrift) MaxMonthlyCostDelta() Money {
+	total := ZeroMoney(DefaultCurrency)
+	for _, rd := range d.RuntimeDrifts {
+		total = total.Add(rd.MaxCostDelta())
+	}
+	for _, ad := range d.AddonDrifts {
+		total = total.Add(ad.CostDelta())
+	}
+	return total
+}
+
+// ListMonthlyCostDelta returns the change of the estimation expected monthly
+// cost at catalog prices, the drift of the catalog alone.
+func (d *EstimationDrift) ListMonthlyCostDelta() Money {
+	total := ZeroMoney(DefaultCurrency)
+	for _, rd := range d.RuntimeDrifts {
+		total = total.Add(rd.ListCostDelta())
+	}
+	for _, ad := range d.AddonDrifts {
+		total = total.Add(ad.ListCostDelta())
+	}
+	return total
+}
@@ -5,12 +5,13 @@ type Flavor struct {
 	Name         string
 	Mem          int32
 	CPUs         int32
-	PricePerHour float64
+	PricePerHour Money
+	PriceID      string
 	Available    bool
 }
 
 // NewFlavor creates a new Flavor instance.
-func NewFlavor(name string, mem, cpus int32, pricePerHour float64, available bool) *Flavor {
+func NewFlavor(name string, mem, cpus int32, pricePerHour Money, available bool) *Flavor {
 	return &Flavor{
 		Name:         name,
 		Mem:          mem,
@@ -20,10 +21,9 @@ func NewFlavor(name string, mem, cpus int32, pricePerHour float64, available boo
 	}
 }
 
-// MonthlyPrice calculates the monthly price for this flavor (730 hours/month).
-func (f *Flavor) MonthlyPrice() float64 {
-	const hoursPerMonth = 730
-	return f.PricePerHour * hoursPerMonth
+// MonthlyPrice calculates the monthly price for this flavor in the given billing calendar.
+func (f *Flavor) MonthlyPrice(calendar *BillingCalendar) Money {
+	return calendar.MonthlyCost(f.PricePerHour)
 }
 
 // IsHighMemory returns true if the flavor has more than 4GB of memory.
@@ -1,11 +1,19 @@
 package entity
 
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 // Instance represents a runtime instance type with its available flavors.
 type Instance struct {
 	Type    string
 	Name    string
 	Version string
 	Flavors []*Flavor
+	// MaxInstances is the most instances a runtime of this type can run, 0 when unknown.
+	MaxInstances int32
 }
 
 // NewInstance creates a new Instance.
@@ -45,28 +53,58 @@ func (i *Instance) FindFlavorByName(name string) *Flavor {
 }
 
 // MinPrice returns the minimum monthly price among all available flavors.
-func (i *Instance) MinPrice() float64 {
-	minPrice := -1.0
+func (i *Instance) MinPrice(calendar *BillingCalendar) Money {
+	var minPrice *Money
 	for _, f := range i.GetAvailableFlavors() {
-		monthlyPrice := f.MonthlyPrice()
-		if minPrice < 0 || monthlyPrice < minPrice {
-			minPrice = monthlyPrice
+		monthlyPrice := f.MonthlyPrice(calendar)
+		if minPrice == nil || monthlyPrice.Cmp(*minPrice) < 0 {
+			minPrice = &monthlyPrice
 		}
 	}
-	if minPrice < 0 {
-		return 0
+	if minPrice == nil {
+		return ZeroMoney(DefaultCurrency)
 	}
-	return minPrice
+	return *minPrice
 }
 
 // MaxPrice returns the maximum monthly price among all available flavors.
-func (i *Instance) MaxPrice() float64 {
-	maxPrice := 0.0
+func (i *Instance) MaxPrice(calendar *BillingCalendar) Money {
+	maxPrice := ZeroMoney(DefaultCurrency)
 	for _, f := range i.GetAvailableFlavors() {
-		monthlyPrice := f.MonthlyPrice()
-		if monthlyPrice > maxPrice {
-			maxPrice = monthlyPrice
-		}
+		maxPrice = MaxMoney(maxPrice, f.MonthlyPrice(calendar))
 	}
 	return maxPrice
 }
+
+// clone returns a copy of the instance with copies of its flavors.
+func (i *Instance) clone() *Instance {
+	clone := *i
+	clone.Flavors = make([]*Flavor, len(i.Flavors))
+	for j, f := range i.Flavors {
+		flavor := *f
+		clone.Flavors[j] = &flavor
+	}
+	return &clone
+}
+
+// ValidateFlavor returns a validation error unless the instance offers the
+// flavor and it is available.
+func (i *Instance) ValidateFlavor(name string) error {
+	flavor := i.FindFlavorByName(name)
+	if flavor == nil {
+		return validation.NewError("validation_flavor_not_found", fmt.Sprintf("must be a flavor of instance type %s", i.Type))
+	}
+	if !flavor.Available {
+		return validation.NewError("validation_flavor_unavailable", fmt.Sprintf("flavor %s of instance type %s is not available", name, i.Type))
+	}
+	return nil
+}
+
+// checkInstances returns a validation error if an instance count exceeds the
+// limit of the instance.
+func (i *Instance) checkInstances(instances int32) error {
+	if i.MaxInstances > 0 && instances > i.MaxInstances {
+		return validation.NewError("validation_max_instances", fmt.Sprintf("must be no greater than %d, the instance limit of %s", i.MaxInstances, i.Type))
+	}
+	return nil
+}
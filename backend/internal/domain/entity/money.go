@@ -0,0 +1,285 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DefaultCurrency is the currency Clever Cloud prices are expressed in.
+const DefaultCurrency = "EUR"
+
+var (
+	// ErrNonFiniteFactor is returned when multiplying an amount by NaN or an infinity.
+	ErrNonFiniteFactor = errors.New("money: non-finite factor")
+	// ErrMoneyOverflow is returned when a result does not fit in a Money.
+	ErrMoneyOverflow = errors.New("money: amount out of range")
+)
+
+const (
+	nanosPerUnit = 1_000_000_000
+	nanosPerCent = nanosPerUnit / 100
+)
+
+// Money represents an exact amount of money as an integer number of nanos
+// (10^-9 units) of its currency.
+//
+// Rounding policy: catalog prices and hourly costs are kept at nano precision,
+// each monthly cost line is rounded to the cent with RoundToCents, and totals
+// are the exact sum of the rounded lines.
+type Money struct {
+	Nanos    int64
+	Currency string
+}
+
+// NewMoney creates a Money from a decimal amount, rounded to the nearest nano.
+func NewMoney(amount float64, currency string) Money {
+	return Money{
+		Nanos:    roundHalfAwayFromZero(amount * nanosPerUnit),
+		Currency: currency,
+	}
+}
+
+// EUR creates a Money of the given amount of euros.
+func EUR(amount float64) Money {
+	return NewMoney(amount, DefaultCurrency)
+}
+
+// ZeroMoney returns a zero amount of the currency.
+func ZeroMoney(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Float64 returns the amount in units of the currency, for display and
+// statistics only.
+func (m Money) Float64() float64 {
+	return float64(m.Nanos) / nanosPerUnit
+}
+
+// IsZero returns true if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Nanos == 0
+}
+
+// IsNegative returns true if the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.Nanos < 0
+}
+
+// Add returns m + o. Amounts must be in the same currency, a zero Money without
+// currency takes the currency of the other operand. Results beyond the range of
+// Money saturate at its bounds, see CheckedAdd for amounts that are not validated.
+func (m Money) Add(o Money) Money {
+	sum, err := m.CheckedAdd(o)
+	if err != nil {
+		return Money{Nanos: saturate(o.Nanos < 0), Currency: m.sameCurrency(o)}
+	}
+	return sum
+}
+
+// CheckedAdd returns m + o, failing with ErrMoneyOverflow when the sum does
+// not fit in a Money.
+func (m Money) CheckedAdd(o Money) (Money, error) {
+	currency := m.sameCurrency(o)
+	nanos := m.Nanos + o.Nanos
+	if (o.Nanos > 0 && nanos < m.Nanos) || (o.Nanos < 0 && nanos > m.Nanos) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Nanos: nanos, Currency: currency}, nil
+}
+
+// Sub returns m - o. Results beyond the range of Money saturate at its bounds,
+// see CheckedSub for amounts that are not validated.
+func (m Money) Sub(o Money) Money {
+	diff, err := m.CheckedSub(o)
+	if err != nil {
+		return Money{Nanos: saturate(o.Nanos > 0), Currency: m.sameCurrency(o)}
+	}
+	return diff
+}
+
+// CheckedSub returns m - o, failing with ErrMoneyOverflow when the difference
+// does not fit in a Money.
+func (m Money) CheckedSub(o Money) (Money, error) {
+	currency := m.sameCurrency(o)
+	nanos := m.Nanos - o.Nanos
+	if (o.Nanos > 0 && nanos > m.Nanos) || (o.Nanos < 0 && nanos < m.Nanos) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Nanos: nanos, Currency: currency}, nil
+}
+
+// Times returns m multiplied by an integer quantity, exactly. Results beyond
+// the range of Money saturate at its bounds, see CheckedTimes for quantities
+// that are not validated.
+func (m Money) Times(n int64) Money {
+	product, err := m.CheckedTimes(n)
+	if err != nil {
+		return Money{Nanos: saturate(m.Nanos < 0 != (n < 0)), Currency: m.Currency}
+	}
+	return product
+}
+
+// CheckedTimes returns m multiplied by an integer quantity, failing with
+// ErrMoneyOverflow when the product does not fit in a Money.
+func (m Money) CheckedTimes(n int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.Nanos), big.NewInt(n))
+	if !product.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Nanos: product.Int64(), Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by a decimal factor, rounded to the nearest nano.
+// Results beyond the range of Money saturate at its bounds. It panics on a
+// factor that is not finite: it is meant for factors the caller controls or has
+// validated, see CheckedMul for the others.
+func (m Money) Mul(factor float64) Money {
+	product, err := m.CheckedMul(factor)
+	switch {
+	case errors.Is(err, ErrNonFiniteFactor):
+		panic(fmt.Sprintf("money: multiplying %s by %v", m, factor))
+	case err != nil:
+		return Money{Nanos: saturate(m.Nanos < 0 != (factor < 0)), Currency: m.Currency}
+	}
+	return product
+}
+
+// CheckedMul returns m multiplied by a decimal factor, rounded to the nearest
+// nano. The product is computed exactly from the binary value of the factor.
+// It fails with ErrNonFiniteFactor for NaN and infinite factors, and with
+// ErrMoneyOverflow when the result does not fit in a Money.
+func (m Money) CheckedMul(factor float64) (Money, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return Money{}, ErrNonFiniteFactor
+	}
+	product := new(big.Rat).SetFloat64(factor)
+	product.Mul(product, new(big.Rat).SetInt64(m.Nanos))
+	nanos := quoRound(product.Num(), product.Denom())
+	if !nanos.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Nanos: nanos.Int64(), Currency: m.Currency}, nil
+}
+
+// MulRatio returns m * num / den, rounded to the nearest nano. It is exact for
+// integer ratios such as hours per month over hours per week. Results beyond
+// the range of Money saturate at its bounds.
+func (m Money) MulRatio(num, den int64) Money {
+	product := new(big.Int).Mul(big.NewInt(m.Nanos), big.NewInt(num))
+	return Money{Nanos: divRound(product, big.NewInt(den)), Currency: m.Currency}
+}
+
+// RoundToCents returns m rounded to the cent, halves away from zero.
+func (m Money) RoundToCents() Money {
+	return Money{Nanos: divRound(big.NewInt(m.Nanos), big.NewInt(nanosPerCent)) * nanosPerCent, Currency: m.Currency}
+}
+
+// Cmp compares m and o, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.Nanos < o.Nanos:
+		return -1
+	case m.Nanos > o.Nanos:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Ratio returns m / o, or 0 when o is zero.
+func (m Money) Ratio(o Money) float64 {
+	if o.Nanos == 0 {
+		return 0
+	}
+	return float64(m.Nanos) / float64(o.Nanos)
+}
+
+// String returns the amount with its significant decimals and currency, e.g. "12.5 EUR".
+func (m Money) String() string {
+	r := new(big.Rat).SetFrac64(m.Nanos, nanosPerUnit)
+	s := r.FloatString(9)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	if m.Currency == "" {
+		return s
+	}
+	return fmt.Sprintf("%s %s", s, m.Currency)
+}
+
+// MaxMoney returns the greater of two amounts.
+func MaxMoney(a, b Money) Money {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// NonNegativeMoney is a validation rule function for amounts that must not be negative.
+func NonNegativeMoney(value interface{}) error {
+	if m, ok := value.(Money); ok && m.IsNegative() {
+		return validation.NewError("validation_money_negative", "must not be negative")
+	}
+	return nil
+}
+
+// PositiveMoney is a validation rule function for amounts that must be positive.
+func PositiveMoney(value interface{}) error {
+	if m, ok := value.(Money); ok && (m.IsNegative() || m.IsZero()) {
+		return validation.NewError("validation_money_not_positive", "must be positive")
+	}
+	return nil
+}
+
+func (m Money) sameCurrency(o Money) string {
+	switch {
+	case m.Currency == o.Currency || o.Currency == "":
+		return m.Currency
+	case m.Currency == "":
+		return o.Currency
+	default:
+		panic(fmt.Sprintf("money: mixing %s and %s amounts", m.Currency, o.Currency))
+	}
+}
+
+// divRound returns x / y rounded to the nearest integer, halves away from
+// zero, saturated to the range of int64.
+func divRound(x, y *big.Int) int64 {
+	q := quoRound(x, y)
+	if !q.IsInt64() {
+		return saturate(q.Sign() < 0)
+	}
+	return q.Int64()
+}
+
+// quoRound returns x / y rounded to the nearest integer, halves away from zero.
+func quoRound(x, y *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Mul(r, big.NewInt(2))).Cmp(new(big.Int).Abs(y)) >= 0 {
+		if (x.Sign() < 0) != (y.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// saturate returns the bound of int64 an out-of-range result is clamped to.
+func saturate(negative bool) int64 {
+	if negative {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+func roundHalfAwayFromZero(x float64) int64 {
+	return int64(math.Round(x))
+}
@@ -0,0 +1,77 @@
+package entity
+
+// Business hours, in the local time of the schedule: Monday to Friday, from
+// BusinessHoursStart to BusinessHoursEnd.
+const (
+	BusinessDaysPerWeek = 5
+	BusinessHoursStart  = 8
+	BusinessHoursEnd    = 20
+)
+
+// IsBusinessHour returns true if an hour of the week, Monday first, is a business hour.
+func IsBusinessHour(day, hour int) bool {
+	return day < BusinessDaysPerWeek && hour >= BusinessHoursStart && hour < BusinessHoursEnd
+}
+
+// OptimizationKind identifies how a suggestion changes a runtime.
+type OptimizationKind string
+
+// Optimization kinds.
+const (
+	// OptimizationKindScalingBaseline runs a smaller baseline that scales up
+	// to the current configuration during business hours.
+	OptimizationKindScalingBaseline OptimizationKind = "scaling_baseline"
+	// OptimizationKindScaleOut runs more instances of a cheaper flavor with at
+	// least the current CPUs and memory.
+	OptimizationKindScaleOut OptimizationKind = "scale_out"
+	// OptimizationKindBusinessHoursOnly turns a staging runtime off outside
+	// business hours.
+	OptimizationKindBusinessHoursOnly OptimizationKind = "business_hours_only"
+)
+
+// ResourceCapacity represents the CPUs and memory of a runtime, summed over its instances.
+type ResourceCapacity struct {
+	CPUs int32
+	// Mem is in MB, like Flavor.Mem.
+	Mem int32
+}
+
+// NewResourceCapacity returns the capacity of instances of a flavor.
+func NewResourceCapacity(flavor *Flavor, instances int32) ResourceCapacity {
+	return ResourceCapacity{CPUs: flavor.CPUs * instances, Mem: flavor.Mem * instances}
+}
+
+// CapacityRange represents the lowest and highest capacity a runtime runs
+// over the hours of its weekly schedule.
+type CapacityRange struct {
+	Min ResourceCapacity
+	Max ResourceCapacity
+}
+
+// OptimizationSuggestion represents a cheaper configuration of a runtime.
+type OptimizationSuggestion struct {
+	Kind OptimizationKind
+	// RuntimeIndex is the position of the runtime in the analysed specs.
+	RuntimeIndex int
+	InstanceType string
+	Description  string
+	// SuggestedSpec replaces the runtime spec to apply the suggestion.
+	SuggestedSpec *RuntimeSpec
+	// Monthly costs are estimated costs, priced like CalculateCost.
+	CurrentMonthlyCost   Money
+	SuggestedMonthlyCost Money
+	MonthlySaving        Money
+	CurrentCapacity      CapacityRange
+	SuggestedCapacity    CapacityRange
+}
+
+// OptimizationReport represents the suggestions for the runtimes of a project.
+type OptimizationReport struct {
+	// Suggestions are sorted by decreasing monthly saving.
+	Suggestions []*OptimizationSuggestion
+	// CurrentMonthlyCost is the estimated monthly cost of the runtimes.
+	CurrentMonthlyCost Money
+	// BestMonthlySaving sums the largest saving of each runtime, as only one
+	// suggestion applies to a runtime.
+	BestMonthlySaving Money
+}
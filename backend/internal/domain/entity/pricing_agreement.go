@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// PricingAgreement represents the negotiated pricing of an organization:
+// prepaid credits, a monthly commitment, a discount on every line and
+// negotiated prices for some flavors.
+type PricingAgreement struct {
+	OrganizationID string
+	// CreditBalance is the remaining prepaid credit.
+	CreditBalance Money
+	// MonthlyCommitment is billed every month, even when the cost is lower.
+	MonthlyCommitment Money
+	// DiscountPercent applies to every line after flavor price overrides.
+	DiscountPercent      float64
+	FlavorPriceOverrides []*FlavorPriceOverride
+	UpdatedAt            time.Time
+}
+
+// FlavorPriceOverride represents a negotiated hourly price of a flavor.
+type FlavorPriceOverride struct {
+	InstanceType string
+	FlavorName   string
+	PricePerHour Money
+}
+
+// Validate validates the override.
+func (o *FlavorPriceOverride) Validate() error {
+	return validation.ValidateStruct(o,
+		validation.Field(&o.InstanceType, validation.Required),
+		validation.Field(&o.FlavorName, validation.Required),
+		validation.Field(&o.PricePerHour, validation.By(NonNegativeMoney)),
+	)
+}
+
+// NewPricingAgreement creates a new PricingAgreement.
+func NewPricingAgreement(organizationID string, creditBalance, monthlyCommitment Money, discountPercent float64, overrides []*FlavorPriceOverride) (*PricingAgreement, error) {
+	if organizationID == "" {
+		return nil, errors.New("organization ID is required")
+	}
+	if creditBalance.IsNegative() {
+		return nil, errors.New("credit balance must not be negative")
+	}
+	if monthlyCommitment.IsNegative() {
+		return nil, errors.New("monthly commitment must not be negative")
+	}
+	if discountPercent < 0 || discountPercent > 100 || math.IsNaN(discountPercent) {
+		return nil, errors.New("discount percent must be between 0 and 100")
+	}
+
+	seen := make(map[[2]string]bool, len(overrides))
+	for _, o := range overrides {
+		if o.InstanceType == "" || o.FlavorName == "" {
+			return nil, errors.New("flavor price override requires an instance type and a flavor name")
+		}
+		if o.PricePerHour.IsNegative() {
+			return nil, fmt.Errorf("price of flavor %s of %s must not be negative", o.FlavorName, o.InstanceType)
+		}
+		key := [2]string{o.InstanceType, o.FlavorName}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate price override for flavor %s of %s", o.FlavorName, o.InstanceType)
+		}
+		seen[key] = true
+	}
+
+	return &PricingAgreement{
+		OrganizationID:       organizationID,
+		CreditBalance:        creditBalance,
+		MonthlyCommitment:    monthlyCommitment,
+		DiscountPercent:      discountPercent,
+		FlavorPriceOverrides: overrides,
+		UpdatedAt:            time.Now(),
+	}, nil
+}
+
+// ApplyFlavorPriceOverrides returns the instance with the negotiated flavor
+// prices, a copy when a price is overridden.
+func (a *PricingAgreement) ApplyFlavorPriceOverrides(instance *Instance) *Instance {
+	var overridden *Instance
+	for _, o := range a.FlavorPriceOverrides {
+		if o.InstanceType != instance.Type {
+			continue
+		}
+		if overridden == nil {
+			overridden = instance.clone()
+		}
+		if f := overridden.FindFlavorByName(o.FlavorName); f != nil {
+			f.PricePerHour = o.PricePerHour
+		}
+	}
+
+	if overridden == nil {
+		return instance
+	}
+	return overridden
+}
+
+// Discount returns an amount after the percentage discount, at nano precision.
+// It fails with an error of Money.CheckedMul when the amount cannot be discounted.
+func (a *PricingAgreement) Discount(amount Money) (Money, error) {
+	if a.DiscountPercent == 0 {
+		return amount, nil
+	}
+	return amount.CheckedMul(1 - a.DiscountPercent/100)
+}
+
+// discountToCents discounts each amount in place, rounded to the cent.
+func (a *PricingAgreement) discountToCents(amounts ...*Money) error {
+	for _, amount := range amounts {
+		discounted, err := a.Discount(*amount)
+		if err != nil {
+			return err
+		}
+		*amount = discounted.RoundToCents()
+	}
+	return nil
+}
+
+// DiscountRuntimeCost applies the percentage discount to the costs of a
+// runtime line, rounded to the cent.
+func (a *PricingAgreement) DiscountRuntimeCost(rc *RuntimeCost) error {
+	if err := a.discountToCents(&rc.MinCost, &rc.MaxCost, &rc.BaseCost, &rc.EstimatedCost); err != nil {
+		return err
+	}
+	rc.ScalingCost = MaxMoney(ZeroMoney(rc.EstimatedCost.Currency), rc.EstimatedCost.Sub(rc.BaseCost))
+
+	unitPrice, err := a.Discount(rc.UnitPrice)
+	if err != nil {
+		return err
+	}
+	rc.UnitPrice = unitPrice
+	return nil
+}
+
+// DiscountAddonCost applies the percentage discount to the plan price and the
+// usage cost of an addon line, rounded to the cent, the cost being their sum.
+// Usage costs per metric keep their list prices.
+func (a *PricingAgreement) DiscountAddonCost(ac *AddonCost) error {
+	if err := a.discountToCents(&ac.UnitPrice, &ac.UsageCost); err != nil {
+		return err
+	}
+	cost, err := ac.UnitPrice.CheckedAdd(ac.UsageCost)
+	if err != nil {
+		return err
+	}
+	ac.Cost = cost
+	return nil
+}
+
+// AgreementSummary represents the effect of a pricing agreement on an estimation.
+type AgreementSummary struct {
+	// ListMonthlyCost is the expected monthly cost at catalog prices.
+	ListMonthlyCost Money
+	// DiscountAmount is the list cost minus the expected monthly cost.
+	DiscountAmount  Money
+	DiscountPercent float64
+	// BilledMonthlyCost is the expected monthly cost, at least the commitment.
+	BilledMonthlyCost Money
+	MonthlyCommitment Money
+	CreditBalance     Money
+	// CreditRunwayMonths is the number of months the credit balance covers the
+	// billed monthly cost, rounded down to one decimal, nil when nothing is billed.
+	CreditRunwayMonths *float64
+}
+
+// ApplyAgreement sets the agreement summary from the lines, which must
+// already be priced with the agreement.
+func (e *CostEstimation) ApplyAgreement(a *PricingAgreement) {
+	listCost := ZeroMoney(DefaultCurrency)
+	for _, rc := range e.RuntimeCosts {
+		listCost = listCost.Add(rc.ListCost)
+	}
+	for _, ac := range e.AddonCosts {
+		listCost = listCost.Add(ac.ListCost)
+	}
+
+	billed := MaxMoney(e.EstimatedMonthlyCost, a.MonthlyCommitment)
+	summary := &AgreementSummary{
+		ListMonthlyCost:   listCost,
+		DiscountAmount:    listCost.Sub(e.EstimatedMonthlyCost),
+		DiscountPercent:   a.DiscountPercent,
+		BilledMonthlyCost: billed,
+		MonthlyCommitment: a.MonthlyCommitment,
+		CreditBalance:     a.CreditBalance,
+	}
+	if !billed.IsZero() {
+		runway := math.Floor(a.CreditBalance.Ratio(billed)*10) / 10
+		summary.CreditRunwayMonths = &runway
+	}
+	e.Agreement = summary
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Weekly schedule dimensions.
+const (
+	DaysPerWeek  = 7
+	HoursPerDay  = 24
+	HoursPerWeek = DaysPerWeek * HoursPerDay
+)
+
+// Load levels of a schedule slot. Level 0 runs the minimum configuration of
+// the profile, level 5 its maximum.
+const (
+	MinLoadLevel = 0
+	MaxLoadLevel = 5
+)
+
+// BaselineConfig represents the fixed configuration of a runtime.
+type BaselineConfig struct {
+	Instances  int32
+	FlavorName string
+}
+
+// ScalingProfile represents reusable autoscaling bounds that schedule slots refer to.
+type ScalingProfile struct {
+	ID   string
+	Name string
+	// Horizontal scaling bounds.
+	MinInstances int32
+	MaxInstances int32
+	// Vertical scaling bounds.
+	MinFlavorName string
+	MaxFlavorName string
+	// Enabled is false when the profile stays on its minimum configuration.
+	Enabled bool
+}
+
+// HourlyConfig represents the scaling configuration of one hour of the week.
+type HourlyConfig struct {
+	// ProfileID is the scaling profile of the slot, empty for the baseline.
+	ProfileID string
+	LoadLevel int32
+}
+
+// WeeklySchedule represents the scaling configuration of every hour of the
+// week, indexed by day (Monday first) then hour. The zero value is a schedule
+// entirely on the baseline.
+type WeeklySchedule [DaysPerWeek][HoursPerDay]HourlyConfig
+
+// NewBaselineConfig creates a new BaselineConfig.
+func NewBaselineConfig(instances int32, flavorName string) *BaselineConfig {
+	return &BaselineConfig{
+		Instances:  instances,
+		FlavorName: flavorName,
+	}
+}
+
+// NewScalingProfile creates a new enabled ScalingProfile.
+func NewScalingProfile(id, name string, minInstances, maxInstances int32, minFlavorName, maxFlavorName string) *ScalingProfile {
+	return &ScalingProfile{
+		ID:            id,
+		Name:          name,
+		MinInstances:  minInstances,
+		MaxInstances:  maxInstances,
+		MinFlavorName: minFlavorName,
+		MaxFlavorName: maxFlavorName,
+		Enabled:       true,
+	}
+}
+
+// Validate validates the baseline.
+func (b *BaselineConfig) Validate() error {
+	return validation.ValidateStruct(b,
+		validation.Field(&b.Instances, validation.Min(int32(0))),
+		validation.Field(&b.FlavorName, validation.Required),
+	)
+}
+
+// Validate validates the profile. A profile may run no instance, to turn a
+// runtime off for some hours.
+func (p *ScalingProfile) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.MinInstances, validation.Min(int32(0))),
+		validation.Field(&p.MaxInstances, validation.By(noFewerThan(p.MinInstances))),
+	)
+}
+
+// ValidateCatalog validates the profile against the flavors and instance
+// limit of its instance. Empty flavor names are not checked.
+func (p *ScalingProfile) ValidateCatalog(instance *Instance) error {
+	errs := validation.Errors{
+		"MaxInstances": instance.checkInstances(p.MaxInstances),
+	}
+	if p.MinFlavorName != "" {
+		errs["MinFlavorName"] = instance.ValidateFlavor(p.MinFlavorName)
+	}
+	if p.MaxFlavorName != "" {
+		errs["MaxFlavorName"] = instance.ValidateFlavor(p.MaxFlavorName)
+	}
+	return errs.Filter()
+}
+
+// noFewerThan checks that an instance count is no less than min. Unlike
+// validation.Min, it also checks zero counts.
+func noFewerThan(min int32) validation.RuleFunc {
+	return func(value interface{}) error {
+		if value.(int32) < min {
+			return validation.NewError("validation_min_greater_equal_than_required", fmt.Sprintf("must be no less than %d", min))
+		}
+		return nil
+	}
+}
+
+// ScalingState represents the configuration a scaling profile runs at a load level.
+type ScalingState struct {
+	LoadLevel  int32
+	FlavorName string
+	// FlavorIndex is the position of the flavor in the profile flavor range, 0 being the minimum flavor.
+	FlavorIndex int
+	Instances   int32
+	HourlyCost  Money
+}
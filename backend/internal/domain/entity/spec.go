@@ -0,0 +1,250 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// legacyProfileID is the ID of the scaling profile derived from a runtime spec
+// that only has min and max instances.
+const legacyProfileID = "default"
+
+// RuntimeSpec represents the configuration of a runtime to price.
+type RuntimeSpec struct {
+	InstanceType string
+	// FlavorName, MinInstances and MaxInstances describe a runtime without
+	// Baseline, see Normalize.
+	FlavorName   string
+	MinInstances int32
+	MaxInstances int32
+	// ZoneID overrides the command zone for this runtime when set.
+	ZoneID string
+
+	Baseline *BaselineConfig
+	// ScalingEnabled selects the weekly schedule instead of the fixed baseline.
+	ScalingEnabled  bool
+	ScalingProfiles []*ScalingProfile
+	// Schedule defaults to a schedule entirely on the baseline.
+	Schedule *WeeklySchedule
+}
+
+// AddonSpec represents the specification for an addon cost calculation.
+type AddonSpec struct {
+	ProviderID string
+	PlanID     string
+	// ZoneID overrides the command zone for this addon when set.
+	ZoneID string
+	// UsageEstimates are the monthly usages of a usage-billed addon, metrics
+	// without an estimate use their default value.
+	UsageEstimates []*UsageEstimate
+}
+
+// Normalize returns a copy of the spec in the zone with Baseline and Schedule set.
+// A spec without Baseline runs MinInstances of FlavorName, and scales up to
+// MaxInstances of the same flavor through a single profile when they differ.
+func (s *RuntimeSpec) Normalize(zoneID string) *RuntimeSpec {
+	normalized := s.Clone()
+	normalized.ZoneID = zoneID
+
+	if normalized.Baseline == nil {
+		normalized.Baseline = NewBaselineConfig(s.MinInstances, s.FlavorName)
+		if !s.ScalingEnabled && s.MaxInstances > s.MinInstances {
+			normalized.ScalingEnabled = true
+			normalized.ScalingProfiles = []*ScalingProfile{
+				NewScalingProfile(legacyProfileID, "Default", s.MinInstances, s.MaxInstances, s.FlavorName, s.FlavorName),
+			}
+		}
+	}
+	if normalized.Schedule == nil {
+		normalized.Schedule = &WeeklySchedule{}
+	}
+
+	return normalized
+}
+
+// DefaultProfile returns the first enabled scaling profile, which applies to
+// baseline slots of the schedule, or nil.
+func (s *RuntimeSpec) DefaultProfile() *ScalingProfile {
+	for _, p := range s.ScalingProfiles {
+		if p.Enabled {
+			return p
+		}
+	}
+	return nil
+}
+
+// FindEnabledProfile finds an enabled scaling profile by its ID.
+func (s *RuntimeSpec) FindEnabledProfile(id string) *ScalingProfile {
+	for _, p := range s.ScalingProfiles {
+		if p.ID == id && p.Enabled {
+			return p
+		}
+	}
+	return nil
+}
+
+// Validate validates the spec on its own, see ValidateCatalog for the checks
+// against the instance. The schedule is only checked when scaling is enabled.
+func (s *RuntimeSpec) Validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.InstanceType, validation.Required),
+		validation.Field(&s.FlavorName, validation.When(s.Baseline == nil, validation.Required)),
+		validation.Field(&s.MinInstances, validation.Min(int32(0))),
+		validation.Field(&s.MaxInstances, validation.Min(int32(0)), validation.When(s.MaxInstances != 0, validation.Min(s.MinInstances))),
+		validation.Field(&s.Baseline),
+		validation.Field(&s.ScalingProfiles, validation.By(validateProfiles)),
+		validation.Field(&s.Schedule, validation.When(s.ScalingEnabled, validation.By(s.validateSchedule))),
+	)
+}
+
+// validateSchedule checks the load level and profile of every hour of the
+// schedule, reported by hour of the week.
+func (s *RuntimeSpec) validateSchedule(value interface{}) error {
+	schedule, _ := value.(*WeeklySchedule)
+	if schedule == nil {
+		return nil
+	}
+
+	profileIDs := make(map[string]bool, len(s.ScalingProfiles))
+	for _, p := range s.ScalingProfiles {
+		profileIDs[p.ID] = true
+	}
+
+	hours := validation.Errors{}
+	for day := range schedule {
+		for hour, slot := range schedule[day] {
+			slotErrs := validation.Errors{
+				"LoadLevel": validation.Validate(slot.LoadLevel, validation.Min(int32(MinLoadLevel)), validation.Max(int32(MaxLoadLevel))),
+			}
+			if slot.ProfileID != "" && !profileIDs[slot.ProfileID] {
+				slotErrs["ProfileID"] = validation.NewError("validation_unknown_profile", "must be the ID of a scaling profile of the runtime")
+			}
+			if err := slotErrs.Filter(); err != nil {
+				hours[strconv.Itoa(day*HoursPerDay+hour)] = err
+			}
+		}
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	return validation.Errors{"Hours": hours}
+}
+
+// validateProfiles validates every scaling profile and checks that no two
+// share an ID.
+func validateProfiles(value interface{}) error {
+	profiles, _ := value.([]*ScalingProfile)
+
+	seen := make(map[string]bool, len(profiles))
+	errs := validation.Errors{}
+	for i, p := range profiles {
+		if p == nil {
+			continue
+		}
+		profileErrs := validation.Errors{}
+		if err := p.Validate(); err != nil {
+			var ok bool
+			if profileErrs, ok = err.(validation.Errors); !ok {
+				return err
+			}
+		}
+		if p.ID != "" && seen[p.ID] {
+			profileErrs["ID"] = validation.NewError("validation_duplicate_profile", "must be unique among the scaling profiles of the runtime")
+		}
+		seen[p.ID] = true
+
+		if len(profileErrs) > 0 {
+			errs[strconv.Itoa(i)] = profileErrs
+		}
+	}
+	return errs.Filter()
+}
+
+// ValidateCatalog validates the spec against the flavors and instance limit
+// of its instance: every flavor it refers to must be offered and available,
+// and instance counts must not exceed the limit of the instance, if any.
+func (s *RuntimeSpec) ValidateCatalog(instance *Instance) error {
+	errs := validation.Errors{}
+	if s.Baseline == nil {
+		errs["FlavorName"] = instance.ValidateFlavor(s.FlavorName)
+		errs["MinInstances"] = instance.checkInstances(s.MinInstances)
+		errs["MaxInstances"] = instance.checkInstances(s.MaxInstances)
+	} else {
+		errs["Baseline"] = validation.Errors{
+			"FlavorName": instance.ValidateFlavor(s.Baseline.FlavorName),
+			"Instances":  instance.checkInstances(s.Baseline.Instances),
+		}.Filter()
+	}
+
+	profiles := validation.Errors{}
+	for i, p := range s.ScalingProfiles {
+		if err := p.ValidateCatalog(instance); err != nil {
+			profiles[strconv.Itoa(i)] = err
+		}
+	}
+	errs["ScalingProfiles"] = profiles.Filter()
+
+	return errs.Filter()
+}
+
+// ValidateCatalog validates the spec against the plans of its provider: the
+// plan must be offered by the provider and available in the zone.
+func (s *AddonSpec) ValidateCatalog(provider *AddonProvider, zoneID string) error {
+	plan := provider.FindPlanByID(s.PlanID)
+	switch {
+	case plan == nil:
+		return validation.Errors{
+			"PlanID": validation.NewError("validation_addon_plan_not_found", fmt.Sprintf("must be a plan of addon provider %s", provider.ID)),
+		}
+	case !plan.IsAvailableIn(zoneID):
+		return validation.Errors{
+			"PlanID": validation.NewError("validation_addon_plan_unavailable", fmt.Sprintf("plan %s is not available in zone %s", s.PlanID, zoneID)),
+		}
+	}
+	return nil
+}
+
+// Validate validates the spec.
+func (s *AddonSpec) Validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.ProviderID, validation.Required),
+		validation.Field(&s.PlanID, validation.Required),
+		validation.Field(&s.UsageEstimates),
+	)
+}
+
+// Clone returns a deep copy of the spec.
+func (s *RuntimeSpec) Clone() *RuntimeSpec {
+	clone := *s
+	if s.Baseline != nil {
+		baseline := *s.Baseline
+		clone.Baseline = &baseline
+	}
+	if s.ScalingProfiles != nil {
+		clone.ScalingProfiles = make([]*ScalingProfile, len(s.ScalingProfiles))
+		for i, p := range s.ScalingProfiles {
+			profile := *p
+			clone.ScalingProfiles[i] = &profile
+		}
+	}
+	if s.Schedule != nil {
+		schedule := *s.Schedule
+		clone.Schedule = &schedule
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the spec.
+func (s *AddonSpec) Clone() *AddonSpec {
+	clone := *s
+	if s.UsageEstimates != nil {
+		clone.UsageEstimates = make([]*UsageEstimate, len(s.UsageEstimates))
+		for i, e := range s.UsageEstimates {
+			estimate := *e
+			clone.UsageEstimates[i] = &estimate
+		}
+	}
+	return &clone
+}
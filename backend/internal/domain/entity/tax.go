@@ -0,0 +1,205 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SellerCountry is the country Clever Cloud invoices from.
+const SellerCountry = "FR"
+
+// TaxTreatment selects how VAT applies to an invoice.
+type TaxTreatment string
+
+// Tax treatments.
+const (
+	// TaxTreatmentStandard charges the VAT rate of the place of supply.
+	TaxTreatmentStandard TaxTreatment = "standard"
+	// TaxTreatmentReverseCharge lets an EU business customer account for the VAT.
+	TaxTreatmentReverseCharge TaxTreatment = "reverse_charge"
+	// TaxTreatmentExempt applies to customers exempted from VAT.
+	TaxTreatmentExempt TaxTreatment = "exempt"
+	// TaxTreatmentOutOfScope applies to customers outside the EU.
+	TaxTreatmentOutOfScope TaxTreatment = "out_of_scope"
+)
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	vatNumberPattern   = regexp.MustCompile(`^[A-Z]{2}[0-9A-Z+*]{2,13}$`)
+)
+
+// TaxProfile represents the billing country and VAT status of an organization.
+type TaxProfile struct {
+	OrganizationID string
+	// Country is the ISO 3166-1 alpha-2 code of the billing country.
+	Country string
+	// VATNumber is the intra-community VAT number of a business customer,
+	// empty for consumers.
+	VATNumber string
+	// Exempt is set for customers exempted from VAT, with the legal ground in
+	// ExemptionReason.
+	Exempt          bool
+	ExemptionReason string
+	UpdatedAt       time.Time
+}
+
+// NewTaxProfile creates a new TaxProfile with a normalized country and VAT number.
+func NewTaxProfile(organizationID, country, vatNumber string, exempt bool, exemptionReason string) (*TaxProfile, error) {
+	if organizationID == "" {
+		return nil, errors.New("organization ID is required")
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if !countryCodePattern.MatchString(country) {
+		return nil, fmt.Errorf("invalid country code %q", country)
+	}
+
+	vatNumber = strings.ToUpper(strings.ReplaceAll(vatNumber, " ", ""))
+	if vatNumber != "" && !vatNumberPattern.MatchString(vatNumber) {
+		return nil, fmt.Errorf("invalid VAT number %q", vatNumber)
+	}
+
+	if exempt && exemptionReason == "" {
+		return nil, errors.New("exemption reason is required for an exempt profile")
+	}
+
+	return &TaxProfile{
+		OrganizationID:  organizationID,
+		Country:         country,
+		VATNumber:       vatNumber,
+		Exempt:          exempt,
+		ExemptionReason: exemptionReason,
+		UpdatedAt:       time.Now(),
+	}, nil
+}
+
+// IsBusiness returns true if the organization has a VAT number.
+func (p *TaxProfile) IsBusiness() bool {
+	return p.VATNumber != ""
+}
+
+// VATRate represents the standard VAT rate of an EU member state.
+type VATRate struct {
+	Country string
+	// Rate is a ratio, 0.2 for 20%.
+	Rate float64
+}
+
+// TaxAssessment represents how VAT applies to the invoices of an organization.
+type TaxAssessment struct {
+	Country   string
+	VATNumber string
+	Treatment TaxTreatment
+	// Rate is the VAT rate charged, 0 unless the treatment is standard.
+	Rate float64
+	// Mention is the legal mention to print on the invoice, if any.
+	Mention string
+}
+
+// TaxedAmount represents an amount before and after tax.
+type TaxedAmount struct {
+	Net   Money
+	Tax   Money
+	Gross Money
+}
+
+// NewTaxedAmount returns the net amount with its tax at the rate, rounded to
+// the cent. It fails with an error of Money.CheckedMul or ErrMoneyOverflow when
+// the amount cannot be taxed.
+func NewTaxedAmount(net Money, rate float64) (TaxedAmount, error) {
+	tax, err := net.CheckedMul(rate)
+	if err != nil {
+		return TaxedAmount{}, err
+	}
+	tax = tax.RoundToCents()
+	gross, err := net.CheckedAdd(tax)
+	if err != nil {
+		return TaxedAmount{}, err
+	}
+	return TaxedAmount{Net: net, Tax: tax, Gross: gross}, nil
+}
+
+// Add returns the sum of two taxed amounts.
+func (a TaxedAmount) Add(o TaxedAmount) TaxedAmount {
+	return TaxedAmount{Net: a.Net.Add(o.Net), Tax: a.Tax.Add(o.Tax), Gross: a.Gross.Add(o.Gross)}
+}
+
+// CostRangeTax represents the taxed amounts of the minimum, estimated and
+// maximum monthly costs.
+type CostRangeTax struct {
+	Min       TaxedAmount
+	Estimated TaxedAmount
+	Max       TaxedAmount
+}
+
+// EstimationTax represents the tax of an estimation: how VAT applies and the
+// taxed totals, which are the sums of the taxed lines.
+type EstimationTax struct {
+	Assessment *TaxAssessment
+	CostRangeTax
+}
+
+// ApplyTax sets the taxed amounts of every line and of the totals. Tax is
+// rounded to the cent per line, like costs, and the net totals are the totals
+// of the estimation. It fails like NewTaxedAmount, leaving the estimation
+// untaxed.
+func (e *CostEstimation) ApplyTax(assessment *TaxAssessment) error {
+	zero := ZeroMoney(DefaultCurrency)
+	total := TaxedAmount{Net: zero, Tax: zero, Gross: zero}
+	estimationTax := &EstimationTax{
+		Assessment:   assessment,
+		CostRangeTax: CostRangeTax{Min: total, Estimated: total, Max: total},
+	}
+
+	runtimeTaxes := make([]*CostRangeTax, len(e.RuntimeCosts))
+	for i, rc := range e.RuntimeCosts {
+		tax, err := newCostRangeTax(rc.MinCost, rc.EstimatedCost, rc.MaxCost, assessment.Rate)
+		if err != nil {
+			return err
+		}
+		runtimeTaxes[i] = tax
+		estimationTax.Min = estimationTax.Min.Add(tax.Min)
+		estimationTax.Estimated = estimationTax.Estimated.Add(tax.Estimated)
+		estimationTax.Max = estimationTax.Max.Add(tax.Max)
+	}
+
+	addonTaxes := make([]*TaxedAmount, len(e.AddonCosts))
+	for i, ac := range e.AddonCosts {
+		tax, err := NewTaxedAmount(ac.Cost, assessment.Rate)
+		if err != nil {
+			return err
+		}
+		addonTaxes[i] = &tax
+		estimationTax.Min = estimationTax.Min.Add(tax)
+		estimationTax.Estimated = estimationTax.Estimated.Add(tax)
+		estimationTax.Max = estimationTax.Max.Add(tax)
+	}
+
+	for i, rc := range e.RuntimeCosts {
+		rc.Tax = runtimeTaxes[i]
+	}
+	for i, ac := range e.AddonCosts {
+		ac.Tax = addonTaxes[i]
+	}
+	e.Tax = estimationTax
+	return nil
+}
+
+// newCostRangeTax returns the taxed amounts of a cost range, failing like NewTaxedAmount.
+func newCostRangeTax(minCost, estimatedCost, maxCost Money, rate float64) (*CostRangeTax, error) {
+	var tax CostRangeTax
+	var err error
+	if tax.Min, err = NewTaxedAmount(minCost, rate); err != nil {
+		return nil, err
+	}
+	if tax.Estimated, err = NewTaxedAmount(estimatedCost, rate); err != nil {
+		return nil, err
+	}
+	if tax.Max, err = NewTaxedAmount(maxCost, rate); err != nil {
+		return nil, err
+	}
+	return &tax, nil
+}
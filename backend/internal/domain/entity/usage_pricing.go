@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// UsageBasedPricing represents the usage metrics an addon provider bills on
+// top of its plan price.
+type UsageBasedPricing struct {
+	ProviderID  string
+	Description string
+	Metrics     []*UsageMetric
+}
+
+// UsageMetric represents a billed usage metric (storage, bandwidth, users, ...).
+type UsageMetric struct {
+	ID   string
+	Name string
+	Unit string
+	// FreeQuota is the usage included for free each month.
+	FreeQuota float64
+	// Tiers are the graduated prices of the usage beyond FreeQuota, lowest first.
+	Tiers []*PricingTier
+	// DefaultValue is the monthly usage assumed when none is estimated.
+	DefaultValue float64
+	MinValue     float64
+	MaxValue     float64
+	Step         float64
+}
+
+// PricingTier represents the unit price of a usage range.
+type PricingTier struct {
+	MinThreshold float64
+	// MaxThreshold is the end of the range, 0 for an unbounded range.
+	MaxThreshold float64
+	PricePerUnit Money
+}
+
+// UsageEstimate represents the estimated monthly usage of a metric.
+type UsageEstimate struct {
+	MetricID string
+	Value    float64
+}
+
+// Validate validates the estimate.
+func (e *UsageEstimate) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.MetricID, validation.Required),
+		validation.Field(&e.Value, validation.Min(0.0), validation.By(finiteFloat)),
+	)
+}
+
+// finiteFloat is a validation rule function for numbers that must not be NaN or infinite.
+func finiteFloat(value interface{}) error {
+	if f, ok := value.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return validation.NewError("validation_not_finite", "must be a finite number")
+	}
+	return nil
+}
+
+// UsageMetricCost represents the monthly cost of a usage metric.
+type UsageMetricCost struct {
+	MetricID   string
+	MetricName string
+	Value      float64
+	Unit       string
+	// FreeQuotaApplied is the part of Value covered by the free quota.
+	FreeQuotaApplied float64
+	Cost             Money
+	// Tiers details the usage billed by each tier.
+	Tiers []*UsageTierCost
+}
+
+// UsageTierCost represents the usage billed by a pricing tier.
+type UsageTierCost struct {
+	Tier     *PricingTier
+	Quantity float64
+	// Cost is Quantity at the tier price, not rounded.
+	Cost Money
+}
+
+// FindMetric finds a usage metric by its ID.
+func (p *UsageBasedPricing) FindMetric(id string) *UsageMetric {
+	for _, m := range p.Metrics {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// Cost returns the monthly cost of a usage of the metric, rounded to the cent:
+// the free quota is deducted, then each tier bills the part of the usage
+// within its range. It fails with an error of Money.CheckedMul when the usage
+// cannot be priced.
+func (m *UsageMetric) Cost(value float64) (Money, error) {
+	tiers, err := m.TierCosts(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return sumTierCosts(tiers)
+}
+
+// TierCosts returns the usage billed by each tier, see Cost. Tiers beyond the
+// usage are omitted.
+func (m *UsageMetric) TierCosts(value float64) ([]*UsageTierCost, error) {
+	remaining := max(0, value-m.FreeQuota)
+
+	costs := make([]*UsageTierCost, 0, len(m.Tiers))
+	for _, tier := range m.Tiers {
+		if remaining <= 0 {
+			break
+		}
+
+		quantity := remaining
+		if tier.MaxThreshold > 0 {
+			quantity = min(remaining, tier.MaxThreshold-tier.MinThreshold)
+		}
+
+		cost, err := tier.PricePerUnit.CheckedMul(quantity)
+		if err != nil {
+			return nil, err
+		}
+		costs = append(costs, &UsageTierCost{
+			Tier:     tier,
+			Quantity: quantity,
+			Cost:     cost,
+		})
+		remaining -= quantity
+	}
+	return costs, nil
+}
+
+// CostOf returns the cost detail of a usage of the metric, failing like Cost.
+func (m *UsageMetric) CostOf(value float64) (*UsageMetricCost, error) {
+	tiers, err := m.TierCosts(value)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := sumTierCosts(tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsageMetricCost{
+		MetricID:         m.ID,
+		MetricName:       m.Name,
+		Value:            value,
+		Unit:             m.Unit,
+		FreeQuotaApplied: min(value, m.FreeQuota),
+		Cost:             cost,
+		Tiers:            tiers,
+	}, nil
+}
+
+// sumTierCosts returns the total of the tier costs, rounded to the cent.
+func sumTierCosts(tiers []*UsageTierCost) (Money, error) {
+	total := ZeroMoney(DefaultCurrency)
+	for _, t := range tiers {
+		var err error
+		if total, err = total.CheckedAdd(t.Cost); err != nil {
+			return Money{}, err
+		}
+	}
+	return total.RoundToCents(), nil
+}
@@ -0,0 +1,28 @@
+package entity
+
+// DefaultZoneID is the zone used when none is specified (Paris).
+const DefaultZoneID = "par"
+
+// Zone represents a Clever Cloud deployment zone.
+type Zone struct {
+	Name        string
+	DisplayName string
+	Country     string
+	CountryCode string
+	City        string
+	Lat         float64
+	Lon         float64
+	Tags        []string
+}
+
+// NewZone creates a new Zone.
+func NewZone(name, displayName, country, countryCode, city string) *Zone {
+	return &Zone{
+		Name:        name,
+		DisplayName: displayName,
+		Country:     country,
+		CountryCode: countryCode,
+		City:        city,
+		Tags:        make([]string, 0),
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+// ZoneCatalog indexes the instances of a zone by type, and their flavors by
+// name. It is built once from a catalog fetch so that every lookup of a
+// request reads the same prices.
+type ZoneCatalog struct {
+	ZoneID    string
+	instances map[string]*Instance
+	flavors   map[string]map[string]*Flavor
+}
+
+// NewZoneCatalog creates a new ZoneCatalog from the instances of a zone.
+func NewZoneCatalog(zoneID string, instances []*Instance) *ZoneCatalog {
+	c := &ZoneCatalog{
+		ZoneID:    zoneID,
+		instances: make(map[string]*Instance, len(instances)),
+		flavors:   make(map[string]map[string]*Flavor, len(instances)),
+	}
+	for _, inst := range instances {
+		if _, ok := c.instances[inst.Type]; ok {
+			continue
+		}
+		c.instances[inst.Type] = inst
+		flavors := make(map[string]*Flavor, len(inst.Flavors))
+		for _, f := range inst.Flavors {
+			if _, ok := flavors[f.Name]; !ok {
+				flavors[f.Name] = f
+			}
+		}
+		c.flavors[inst.Type] = flavors
+	}
+	return c
+}
+
+// FindInstance finds an instance by its type, or returns nil.
+func (c *ZoneCatalog) FindInstance(instanceType string) *Instance {
+	return c.instances[instanceType]
+}
+
+// FindFlavor finds a flavor of an instance type by its name, or returns nil.
+func (c *ZoneCatalog) FindFlavor(instanceType, flavorName string) *Flavor {
+	return c.flavors[instanceType][flavorName]
+}
@@ -2,20 +2,87 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
 )
 
+// ErrNotInCatalog is wrapped by catalog lookups for an instance, flavor, zone,
+// addon provider or plan that the catalog does not offer.
+var ErrNotInCatalog = errors.New("not in catalog")
+
 // PricingRepository defines the interface for fetching pricing data.
 type PricingRepository interface {
 	// ListInstances returns all available instances for a given zone.
 	ListInstances(ctx context.Context, zoneID string) ([]*entity.Instance, error)
 
-	// GetInstanceByType returns an instance by its type.
-	GetInstanceByType(ctx context.Context, instanceType string) (*entity.Instance, error)
+	// GetInstanceByType returns an instance of a given zone by its type.
+	GetInstanceByType(ctx context.Context, zoneID, instanceType string) (*entity.Instance, error)
+}
 
-	// GetFlavorPrice returns the hourly price for a specific instance type and flavor.
-	GetFlavorPrice(ctx context.Context, instanceType, flavorName string) (float64, error)
+// AddonCatalogRepository defines the interface for fetching addon providers and their plans.
+type AddonCatalogRepository interface {
+	// ListAddonProviders returns all released addon providers.
+	ListAddonProviders(ctx context.Context) ([]*entity.AddonProvider, error)
+}
+
+// FindAddonPlan looks up a provider plan in an addon catalog and checks it is
+// available in the zone.
+func FindAddonPlan(providers []*entity.AddonProvider, zoneID, providerID, planID string) (*entity.AddonProvider, *entity.AddonPlan, error) {
+	for _, p := range providers {
+		if p.ID != providerID {
+			continue
+		}
+
+		plan := p.FindPlanByID(planID)
+		if plan == nil {
+			return nil, nil, fmt.Errorf("plan %s not found for addon provider %s: %w", planID, providerID, ErrNotInCatalog)
+		}
+		if !plan.IsAvailableIn(zoneID) {
+			return nil, nil, fmt.Errorf("plan %s of addon provider %s is not available in zone %s: %w", planID, providerID, zoneID, ErrNotInCatalog)
+		}
+
+		return p, plan, nil
+	}
+
+	return nil, nil, fmt.Errorf("addon provider %s not found: %w", providerID, ErrNotInCatalog)
+}
+
+// UsagePricingRepository defines the interface for fetching the usage-based
+// pricing of addon providers.
+type UsagePricingRepository interface {
+	// ListUsagePricing returns the usage-based pricing of every addon provider billed by usage.
+	ListUsagePricing(ctx context.Context) ([]*entity.UsageBasedPricing, error)
+
+	// GetUsagePricing returns the usage-based pricing of an addon provider, or
+	// nil if the provider is not billed by usage.
+	GetUsagePricing(ctx context.Context, providerID string) (*entity.UsageBasedPricing, error)
+}
+
+// ZoneRepository defines the interface for fetching deployment zones.
+type ZoneRepository interface {
+	// ListZones returns all deployment zones.
+	ListZones(ctx context.Context) ([]*entity.Zone, error)
+}
+
+// CatalogHistoryRepository defines the interface for storing catalog snapshots over time.
+type CatalogHistoryRepository interface {
+	// Save stores a catalog snapshot and returns its ID.
+	Save(ctx context.Context, snapshot *entity.CatalogSnapshot) (string, error)
+
+	// FindByID retrieves a catalog snapshot by its ID.
+	FindByID(ctx context.Context, id string) (*entity.CatalogSnapshot, error)
+
+	// FindLatest retrieves the most recent catalog snapshot.
+	FindLatest(ctx context.Context) (*entity.CatalogSnapshot, error)
+
+	// FindAt retrieves the most recent catalog snapshot generated at or before t.
+	FindAt(ctx context.Context, t time.Time) (*entity.CatalogSnapshot, error)
+
+	// List retrieves all catalog snapshots, oldest first.
+	List(ctx context.Context) ([]*entity.CatalogSnapshot, error)
 }
 
 // EstimationRepository defines the interface for storing and retrieving estimations.
@@ -29,6 +96,52 @@ type EstimationRepository interface {
 	// FindByProjectID retrieves all estimations for a project.
 	FindByProjectID(ctx context.Context, projectID string) ([]*entity.CostEstimation, error)
 
+	// FindByOrganizationID retrieves all estimations for the projects of an organization.
+	FindByOrganizationID(ctx context.Context, organizationID string) ([]*entity.CostEstimation, error)
+
 	// Delete removes a cost estimation by its ID.
 	Delete(ctx context.Context, id string) error
 }
+
+// BudgetRepository defines the interface for storing budgets and their alerts.
+type BudgetRepository interface {
+	// SaveBudget stores a budget, replacing the budget of the same organization and project.
+	SaveBudget(ctx context.Context, budget *entity.Budget) error
+
+	// FindBudget retrieves the budget of an organization, or of one of its
+	// projects when projectID is set. It returns nil if there is no budget.
+	FindBudget(ctx context.Context, organizationID, projectID string) (*entity.Budget, error)
+
+	// SaveAlert stores a budget alert.
+	SaveAlert(ctx context.Context, alert *entity.BudgetAlert) error
+
+	// ListAlerts retrieves the budget alerts of an organization, oldest first.
+	ListAlerts(ctx context.Context, organizationID string) ([]*entity.BudgetAlert, error)
+}
+
+// TaxProfileRepository defines the interface for storing the tax profiles of organizations.
+type TaxProfileRepository interface {
+	// SaveTaxProfile stores the tax profile of an organization, replacing the previous one.
+	SaveTaxProfile(ctx context.Context, profile *entity.TaxProfile) error
+
+	// FindTaxProfile retrieves the tax profile of an organization, or nil if it has none.
+	FindTaxProfile(ctx context.Context, organizationID string) (*entity.TaxProfile, error)
+}
+
+// VATRateRepository defines the interface for fetching VAT rates.
+type VATRateRepository interface {
+	// ListVATRates returns the standard VAT rates of the EU member states.
+	ListVATRates(ctx context.Context) ([]*entity.VATRate, error)
+
+	// GetVATRate returns the standard VAT rate of a country, or nil outside the EU.
+	GetVATRate(ctx context.Context, country string) (*entity.VATRate, error)
+}
+
+// PricingAgreementRepository defines the interface for storing the pricing agreements of organizations.
+type PricingAgreementRepository interface {
+	// SavePricingAgreement stores the pricing agreement of an organization, replacing the previous one.
+	SavePricingAgreement(ctx context.Context, agreement *entity.PricingAgreement) error
+
+	// FindPricingAgreement retrieves the pricing agreement of an organization, or nil if it has none.
+	FindPricingAgreement(ctx context.Context, organizationID string) (*entity.PricingAgreement, error)
+}
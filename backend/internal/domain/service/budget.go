@@ -0,0 +1,66 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// BudgetEvaluator evaluates the saved estimations of an organization or
+// project against its budget.
+//
+// A project costs what its latest saved estimation says, so an organization
+// costs the sum of the latest estimation of each of its projects.
+type BudgetEvaluator struct{}
+
+// NewBudgetEvaluator creates a new BudgetEvaluator.
+func NewBudgetEvaluator() *BudgetEvaluator {
+	return &BudgetEvaluator{}
+}
+
+// Evaluate returns the evaluation of the estimations within the scope of the budget.
+func (e *BudgetEvaluator) Evaluate(budget *entity.Budget, estimations []*entity.CostEstimation) *entity.BudgetEvaluation {
+	// Latest estimation of each project
+	latest := make(map[string]*entity.CostEstimation)
+	var projectIDs []string
+	for _, est := range estimations {
+		if !inBudgetScope(budget, est) {
+			continue
+		}
+		current, ok := latest[est.ProjectID]
+		if !ok {
+			projectIDs = append(projectIDs, est.ProjectID)
+		}
+		if !ok || est.PricedAt.After(current.PricedAt) {
+			latest[est.ProjectID] = est
+		}
+	}
+
+	slices.Sort(projectIDs)
+
+	zero := entity.ZeroMoney(budget.MonthlyLimit.Currency)
+	minCost, expectedCost, maxCost := zero, zero, zero
+	estimationIDs := make([]string, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		est := latest[projectID]
+		estimationIDs = append(estimationIDs, est.ID)
+		minCost = minCost.Add(est.MinMonthlyCost)
+		expectedCost = expectedCost.Add(entity.MaxMoney(est.EstimatedMonthlyCost, est.MinMonthlyCost))
+		maxCost = maxCost.Add(est.MaxMonthlyCost)
+	}
+
+	return &entity.BudgetEvaluation{
+		Budget:        budget,
+		EstimationIDs: estimationIDs,
+		Min:           budget.Evaluate(minCost),
+		Expected:      budget.Evaluate(expectedCost),
+		Max:           budget.Evaluate(maxCost),
+	}
+}
+
+func inBudgetScope(budget *entity.Budget, est *entity.CostEstimation) bool {
+	if est.OrganizationID != budget.OrganizationID {
+		return false
+	}
+	return !budget.IsProjectBudget() || est.ProjectID == budget.ProjectID
+}
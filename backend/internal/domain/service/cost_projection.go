@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// CostProjector projects the monthly costs of an estimation over several
+// calendar months.
+//
+// Runtimes are billed by the hour, so their costs are prorated from the hours
+// of the estimation calendar to the real hours of each month. Traffic scales
+// the expected cost of a runtime above its minimum, capped at its maximum,
+// since a runtime neither runs below its minimum configuration nor above its
+// maximum one. Addon plans are billed monthly and only their usage-billed part
+// scales with traffic.
+type CostProjector struct{}
+
+// NewCostProjector creates a new CostProjector.
+func NewCostProjector() *CostProjector {
+	return &CostProjector{}
+}
+
+// Project returns the costs of the estimation, priced with the given calendar,
+// for the given number of months from a start month. Traffic grows by
+// growthRate each month and is multiplied by the seasonal multiplier of its
+// calendar month, January first, when seasonal is not empty. It fails with
+// entity.ErrMoneyOverflow when traffic grows an addon cost out of range.
+func (p *CostProjector) Project(
+	estimation *entity.CostEstimation,
+	calendar *entity.BillingCalendar,
+	startYear int,
+	startMonth time.Month,
+	months int,
+	growthRate float64,
+	seasonal []float64,
+) (*entity.CostProjection, error) {
+	zero := entity.ZeroMoney(entity.DefaultCurrency)
+	projection := &entity.CostProjection{
+		EstimationID:      estimation.ID,
+		MonthlyGrowthRate: growthRate,
+		Months:            make([]*entity.MonthlyCostProjection, 0, months),
+		TotalMinCost:      zero,
+		TotalExpectedCost: zero,
+		TotalMaxCost:      zero,
+	}
+
+	monthCalendar := &entity.BillingCalendar{
+		Kind:     entity.BillingCalendarCalendarMonth,
+		Location: calendar.Location,
+	}
+
+	for k := 0; k < months; k++ {
+		start := time.Date(startYear, startMonth+time.Month(k), 1, 0, 0, 0, 0, time.UTC)
+		month := monthCalendar.ForMonth(start.Year(), start.Month())
+
+		factor := math.Pow(1+growthRate, float64(k))
+		if len(seasonal) > 0 {
+			factor *= seasonal[start.Month()-time.January]
+		}
+		// Keep the factor finite, the costs it scales are capped or checked
+		factor = min(factor, math.MaxFloat64)
+
+		m, err := p.projectMonth(estimation, calendar, month, factor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to project %d-%02d: %w", month.Year, month.Month, err)
+		}
+		projection.Months = append(projection.Months, m)
+		if err := accumulate(m.MinCost, &projection.TotalMinCost); err != nil {
+			return nil, fmt.Errorf("total min cost: %w", err)
+		}
+		if err := accumulate(m.ExpectedCost, &projection.TotalExpectedCost); err != nil {
+			return nil, fmt.Errorf("total expected cost: %w", err)
+		}
+		if err := accumulate(m.MaxCost, &projection.TotalMaxCost); err != nil {
+			return nil, fmt.Errorf("total max cost: %w", err)
+		}
+	}
+
+	return projection, nil
+}
+
+// projectMonth returns the costs of the estimation in a month at a traffic
+// factor. Each line is rounded to the cent like in the estimation.
+func (p *CostProjector) projectMonth(estimation *entity.CostEstimation, from, month *entity.BillingCalendar, factor float64) (*entity.MonthlyCostProjection, error) {
+	zero := entity.ZeroMoney(entity.DefaultCurrency)
+	m := &entity.MonthlyCostProjection{
+		Year:          month.Year,
+		Month:         month.Month,
+		Hours:         month.HoursPerMonth(),
+		TrafficFactor: factor,
+		MinCost:       zero,
+		ExpectedCost:  zero,
+		MaxCost:       zero,
+	}
+
+	for _, rc := range estimation.RuntimeCosts {
+		// Estimations without an expected cost run at their minimum
+		estimated := entity.MaxMoney(rc.EstimatedCost, rc.MinCost)
+		maxCost := entity.MaxMoney(rc.MaxCost, rc.MinCost)
+		expected := maxCost
+		if scaling, err := estimated.Sub(rc.MinCost).CheckedMul(factor); err == nil && scaling.Cmp(maxCost.Sub(rc.MinCost)) < 0 {
+			expected = rc.MinCost.Add(scaling)
+		}
+
+		if err := accumulate(month.Prorate(rc.MinCost, from).RoundToCents(), &m.MinCost); err != nil {
+			return nil, fmt.Errorf("minimum cost with runtime %s: %w", rc.RuntimeID, err)
+		}
+		if err := accumulate(month.Prorate(expected, from).RoundToCents(), &m.ExpectedCost); err != nil {
+			return nil, fmt.Errorf("expected cost with runtime %s: %w", rc.RuntimeID, err)
+		}
+		if err := accumulate(month.Prorate(rc.MaxCost, from).RoundToCents(), &m.MaxCost); err != nil {
+			return nil, fmt.Errorf("maximum cost with runtime %s: %w", rc.RuntimeID, err)
+		}
+	}
+
+	for _, ac := range estimation.AddonCosts {
+		usageCost, err := ac.UsageCost.CheckedMul(factor)
+		if err != nil {
+			return nil, fmt.Errorf("usage cost of addon %s: %w", ac.AddonID, err)
+		}
+		cost, err := ac.Cost.Sub(ac.UsageCost).CheckedAdd(usageCost)
+		if err != nil {
+			return nil, fmt.Errorf("cost of addon %s: %w", ac.AddonID, err)
+		}
+		cost = cost.RoundToCents()
+		if err := accumulate(cost, &m.MinCost, &m.ExpectedCost, &m.MaxCost); err != nil {
+			return nil, fmt.Errorf("costs with addon %s: %w", ac.AddonID, err)
+		}
+	}
+
+	return m, nil
+}
+
+// accumulate adds an amount to each total, failing with
+// entity.ErrMoneyOverflow when a sum is out of range.
+func accumulate(amount entity.Money, totals ...*entity.Money) error {
+	for _, total := range totals {
+		sum, err := total.CheckedAdd(amount)
+		if err != nil {
+			return err
+		}
+		*total = sum
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+func projectionEstimation(addonUsageCost float64) *entity.CostEstimation {
+	estimation := entity.NewCostEstimation("project")
+	runtimeCost := entity.NewRuntimeCost("node-S", "node (S)", entity.EUR(28.8), entity.EUR(115.2))
+	runtimeCost.EstimatedCost = entity.EUR(36.51)
+	estimation.AddRuntimeCost(runtimeCost)
+
+	addonCost := entity.NewAddonCost("fs-bucket-plan", "FS Bucket (S)", entity.EUR(5).Add(entity.EUR(addonUsageCost)))
+	addonCost.UsageCost = entity.EUR(addonUsageCost)
+	estimation.AddAddonCost(addonCost)
+	return estimation
+}
+
+func TestProjectHugeGrowthCapsRuntimesAtMaxCost(t *testing.T) {
+	calendar := &entity.BillingCalendar{Kind: entity.BillingCalendarFixed720, Location: time.UTC}
+
+	// The traffic factor overflows float64 after a few months
+	projection, err := NewCostProjector().Project(projectionEstimation(0), calendar, 2026, time.January, 120, 1e6, nil)
+	if err != nil {
+		t.Fatalf("Project() error = %v", err)
+	}
+
+	// Traffic grows from the second month on
+	for _, m := range projection.Months[1:] {
+		if m.MinCost.IsNegative() || m.ExpectedCost.Cmp(m.MaxCost) != 0 {
+			t.Fatalf("%d-%02d: min %s, expected %s, want the max %s", m.Year, m.Month, m.MinCost, m.ExpectedCost, m.MaxCost)
+		}
+	}
+}
+
+func TestProjectUsageCostOutOfRange(t *testing.T) {
+	calendar := &entity.BillingCalendar{Kind: entity.BillingCalendarFixed720, Location: time.UTC}
+
+	_, err := NewCostProjector().Project(projectionEstimation(20.13), calendar, 2026, time.January, 120, 1, nil)
+	if !errors.Is(err, entity.ErrMoneyOverflow) {
+		t.Fatalf("Project() error = %v, want %v", err, entity.ErrMoneyOverflow)
+	}
+}
+
+func TestProjectRuntimeCostOutOfRange(t *testing.T) {
+	calendar := &entity.BillingCalendar{Kind: entity.BillingCalendarFixed720, Location: time.UTC}
+	estimation := projectionEstimation(0)
+	for _, maxCost := range []float64{5e9, 5e9} {
+		runtimeCost := entity.NewRuntimeCost("node-XL", "node (XL)", entity.EUR(28.8), entity.EUR(maxCost))
+		runtimeCost.EstimatedCost = entity.EUR(36.51)
+		estimation.AddRuntimeCost(runtimeCost)
+	}
+
+	_, err := NewCostProjector().Project(estimation, calendar, 2026, time.January, 1, 0, nil)
+	if !errors.Is(err, entity.ErrMoneyOverflow) {
+		t.Fatalf("Project() error = %v, want %v", err, entity.ErrMoneyOverflow)
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// RuntimeLoadCosts represents a runtime in a cost simulation: the hourly cost
+// of every slot of the week at every load level, and how its load is drawn.
+// Slots are indexed like entity.WeeklySchedule.
+type RuntimeLoadCosts struct {
+	// SlotCosts are the hourly costs of each slot by load level.
+	SlotCosts [entity.DaysPerWeek][entity.HoursPerDay][entity.MaxLoadLevel + 1]entity.Money
+	// LoadLevels are the scheduled load levels of slots without a distribution.
+	LoadLevels [entity.DaysPerWeek][entity.HoursPerDay]int32
+	// LoadModel draws the load level of each slot, nil for the scheduled levels.
+	LoadModel *entity.WeeklyLoadModel
+}
+
+// CostSimulator runs Monte Carlo simulations of the monthly cost of a project.
+//
+// Each simulation draws the load level of every slot of a week from its
+// distribution, and prorates the cost of that week to the month like the cost
+// estimation does. Simulation i is seeded from (seed, i), so results only
+// depend on the seed and not on how simulations are spread over goroutines.
+type CostSimulator struct{}
+
+// NewCostSimulator creates a new CostSimulator.
+func NewCostSimulator() *CostSimulator {
+	return &CostSimulator{}
+}
+
+// Simulate runs the given number of simulations of the runtimes, on top of a
+// fixed monthly cost, and summarizes their monthly costs in a histogram of the
+// given number of buckets.
+func (s *CostSimulator) Simulate(
+	ctx context.Context,
+	runtimes []*RuntimeLoadCosts,
+	fixedMonthlyCost entity.Money,
+	calendar *entity.BillingCalendar,
+	iterations int,
+	seed int64,
+	buckets int,
+) (*entity.CostSimulation, error) {
+	costs := make([]entity.Money, iterations)
+
+	workers := min(runtime.GOMAXPROCS(0), iterations)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < iterations; i += workers {
+				if ctx.Err() != nil {
+					return
+				}
+				costs[i] = s.simulateMonth(runtimes, fixedMonthlyCost, calendar, seed, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(costs, entity.Money.Cmp)
+
+	total := 0.0
+	for _, c := range costs {
+		total += c.Float64()
+	}
+
+	return &entity.CostSimulation{
+		Iterations: iterations,
+		Seed:       seed,
+		Mean:       entity.NewMoney(total/float64(iterations), fixedMonthlyCost.Currency).RoundToCents(),
+		Min:        costs[0],
+		Max:        costs[len(costs)-1],
+		P50:        percentile(costs, 50),
+		P90:        percentile(costs, 90),
+		P99:        percentile(costs, 99),
+		Histogram:  histogram(costs, buckets),
+	}, nil
+}
+
+// simulateMonth returns the monthly cost of simulation i.
+func (s *CostSimulator) simulateMonth(runtimes []*RuntimeLoadCosts, fixedMonthlyCost entity.Money, calendar *entity.BillingCalendar, seed int64, i int) entity.Money {
+	rng := rand.New(rand.NewPCG(uint64(seed), uint64(i)))
+
+	monthlyCost := fixedMonthlyCost
+	for _, rt := range runtimes {
+		weeklyCost := entity.ZeroMoney(fixedMonthlyCost.Currency)
+		for day := range rt.SlotCosts {
+			for hour := range rt.SlotCosts[day] {
+				level := rt.LoadLevels[day][hour]
+				if rt.LoadModel != nil && rt.LoadModel[day][hour] != nil {
+					level = drawLoadLevel(rt.LoadModel[day][hour], rng.Float64())
+				}
+				weeklyCost = weeklyCost.Add(rt.SlotCosts[day][hour][level])
+			}
+		}
+		monthlyCost = monthlyCost.Add(calendar.MonthlyCostOfWeek(weeklyCost).RoundToCents())
+	}
+	return monthlyCost
+}
+
+// drawLoadLevel returns the load level at u, uniform in [0, 1), of the
+// cumulative distribution.
+func drawLoadLevel(d *entity.LoadDistribution, u float64) int32 {
+	total := 0.0
+	for _, w := range d {
+		total += w
+	}
+
+	target := u * total
+	cumulative := 0.0
+	last := int32(entity.MinLoadLevel)
+	for level, w := range d {
+		if w == 0 {
+			continue
+		}
+		cumulative += w
+		last = int32(level)
+		if target < cumulative {
+			break
+		}
+	}
+	return last
+}
+
+// percentile returns the nearest-rank percentile p of sorted costs.
+func percentile(sorted []entity.Money, p int) entity.Money {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank-1, 0)]
+}
+
+// histogram splits sorted costs in buckets of equal width, rounded up to the
+// cent, so there may be fewer buckets than requested.
+func histogram(sorted []entity.Money, buckets int) []*entity.CostHistogramBucket {
+	minCost, maxCost := sorted[0], sorted[len(sorted)-1]
+	if minCost.Cmp(maxCost) == 0 {
+		return []*entity.CostHistogramBucket{{LowerBound: minCost, UpperBound: maxCost, Count: len(sorted)}}
+	}
+
+	cent := entity.NewMoney(0.01, minCost.Currency).Nanos
+	span := maxCost.Sub(minCost).Nanos
+	width := (span + int64(buckets) - 1) / int64(buckets)
+	width = (width + cent - 1) / cent * cent
+	count := int((span + width - 1) / width)
+
+	result := make([]*entity.CostHistogramBucket, count)
+	for i := range result {
+		lower := minCost.Add(entity.Money{Nanos: width * int64(i)})
+		result[i] = &entity.CostHistogramBucket{
+			LowerBound: lower,
+			UpperBound: lower.Add(entity.Money{Nanos: width}),
+		}
+	}
+	for _, c := range sorted {
+		i := min(int(c.Sub(minCost).Nanos/width), count-1)
+		result[i].Count++
+	}
+	return result
+}
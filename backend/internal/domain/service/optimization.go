@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// Scaling profile IDs of suggested specs.
+const (
+	scalingProfileID  = "scaling"
+	baselineProfileID = "baseline"
+	offHoursProfileID = "off-hours"
+)
+
+// OptimizationCandidate represents an alternative spec of a runtime, to be
+// priced against the current one.
+type OptimizationCandidate struct {
+	Kind        entity.OptimizationKind
+	Description string
+	Spec        *entity.RuntimeSpec
+}
+
+// OptimizationAdvisor proposes cheaper configurations of runtimes from the
+// flavors of their instance.
+type OptimizationAdvisor struct{}
+
+// NewOptimizationAdvisor creates a new OptimizationAdvisor.
+func NewOptimizationAdvisor() *OptimizationAdvisor {
+	return &OptimizationAdvisor{}
+}
+
+// Candidates returns the alternatives to a normalized runtime spec. Business
+// hours only alternatives are proposed for staging runtimes.
+func (a *OptimizationAdvisor) Candidates(spec *entity.RuntimeSpec, instance *entity.Instance, staging bool) []*OptimizationCandidate {
+	var candidates []*OptimizationCandidate
+	if c := a.scalingBaseline(spec, instance); c != nil {
+		candidates = append(candidates, c)
+	}
+	if c := a.scaleOut(spec, instance); c != nil {
+		candidates = append(candidates, c)
+	}
+	if staging {
+		candidates = append(candidates, a.businessHoursOnly(spec))
+	}
+	return candidates
+}
+
+// scalingBaseline runs a fixed runtime on one instance at rest, of a cheaper
+// flavor when it already runs one instance, and scales it up to its current
+// configuration during business hours.
+func (a *OptimizationAdvisor) scalingBaseline(spec *entity.RuntimeSpec, instance *entity.Instance) *OptimizationCandidate {
+	if spec.ScalingEnabled || spec.Baseline.Instances == 0 {
+		return nil
+	}
+
+	baseline := spec.Baseline
+	minFlavorName := baseline.FlavorName
+	if baseline.Instances == 1 {
+		cheaper := cheaperFlavor(instance, baseline.FlavorName)
+		if cheaper == nil {
+			return nil
+		}
+		minFlavorName = cheaper.Name
+	}
+
+	suggested := suggestedSpec(spec)
+	suggested.ScalingEnabled = true
+	suggested.ScalingProfiles = []*entity.ScalingProfile{
+		entity.NewScalingProfile(scalingProfileID, "Scaling", 1, baseline.Instances, minFlavorName, baseline.FlavorName),
+	}
+	suggested.Schedule = &entity.WeeklySchedule{}
+	for day := range suggested.Schedule {
+		for hour := range suggested.Schedule[day] {
+			slot := entity.HourlyConfig{ProfileID: scalingProfileID, LoadLevel: entity.MinLoadLevel}
+			if entity.IsBusinessHour(day, hour) {
+				slot.LoadLevel = entity.MaxLoadLevel
+			}
+			suggested.Schedule[day][hour] = slot
+		}
+	}
+
+	return &OptimizationCandidate{
+		Kind: entity.OptimizationKindScalingBaseline,
+		Description: fmt.Sprintf("Run 1×%s at rest and scale up to %d×%s during business hours",
+			minFlavorName, baseline.Instances, baseline.FlavorName),
+		Spec: suggested,
+	}
+}
+
+// scaleOut runs a fixed runtime on the cheapest set of instances of a smaller
+// flavor with at least its current CPUs and memory.
+func (a *OptimizationAdvisor) scaleOut(spec *entity.RuntimeSpec, instance *entity.Instance) *OptimizationCandidate {
+	if spec.ScalingEnabled || spec.Baseline.Instances == 0 {
+		return nil
+	}
+
+	baseline := spec.Baseline
+	current := instance.FindFlavorByName(baseline.FlavorName)
+	if current == nil {
+		return nil
+	}
+	cpus := current.CPUs * baseline.Instances
+	mem := current.Mem * baseline.Instances
+
+	bestCost := current.PricePerHour.Times(int64(baseline.Instances))
+	var best *entity.Flavor
+	var bestInstances int32
+	for _, f := range instance.GetAvailableFlavors() {
+		if f.CPUs <= 0 || f.Mem <= 0 {
+			continue
+		}
+		instances := max(ceilDiv(cpus, f.CPUs), ceilDiv(mem, f.Mem))
+		if instances <= baseline.Instances || (instance.MaxInstances > 0 && instances > instance.MaxInstances) {
+			continue
+		}
+		cost := f.PricePerHour.Times(int64(instances))
+		if cost.Cmp(bestCost) < 0 {
+			best, bestInstances, bestCost = f, instances, cost
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	suggested := suggestedSpec(spec)
+	suggested.Baseline = entity.NewBaselineConfig(bestInstances, best.Name)
+
+	return &OptimizationCandidate{
+		Kind: entity.OptimizationKindScaleOut,
+		Description: fmt.Sprintf("Run %d×%s instead of %d×%s, with at least the same CPUs and memory on smaller instances",
+			bestInstances, best.Name, baseline.Instances, baseline.FlavorName),
+		Spec: suggested,
+	}
+}
+
+// businessHoursOnly turns a runtime off outside business hours through a
+// profile without instances. A fixed runtime runs its baseline during
+// business hours, a scaling runtime keeps its schedule.
+func (a *OptimizationAdvisor) businessHoursOnly(spec *entity.RuntimeSpec) *OptimizationCandidate {
+	suggested := suggestedSpec(spec)
+	if !spec.ScalingEnabled || spec.DefaultProfile() == nil {
+		baseline := spec.Baseline
+		suggested.ScalingEnabled = true
+		suggested.ScalingProfiles = []*entity.ScalingProfile{
+			entity.NewScalingProfile(baselineProfileID, "Baseline", baseline.Instances, baseline.Instances, baseline.FlavorName, baseline.FlavorName),
+		}
+		suggested.Schedule = &entity.WeeklySchedule{}
+		for day := range suggested.Schedule {
+			for hour := range suggested.Schedule[day] {
+				suggested.Schedule[day][hour] = entity.HourlyConfig{ProfileID: baselineProfileID, LoadLevel: entity.MinLoadLevel}
+			}
+		}
+	}
+
+	offID := uniqueProfileID(suggested, offHoursProfileID)
+	suggested.ScalingProfiles = append(suggested.ScalingProfiles,
+		entity.NewScalingProfile(offID, "Off hours", 0, 0, spec.Baseline.FlavorName, spec.Baseline.FlavorName))
+	for day := range suggested.Schedule {
+		for hour := range suggested.Schedule[day] {
+			if !entity.IsBusinessHour(day, hour) {
+				suggested.Schedule[day][hour] = entity.HourlyConfig{ProfileID: offID, LoadLevel: entity.MinLoadLevel}
+			}
+		}
+	}
+
+	return &OptimizationCandidate{
+		Kind: entity.OptimizationKindBusinessHoursOnly,
+		Description: fmt.Sprintf("Turn the runtime off outside business hours, Monday to Friday from %d:00 to %d:00",
+			entity.BusinessHoursStart, entity.BusinessHoursEnd),
+		Spec: suggested,
+	}
+}
+
+// suggestedSpec returns a copy of a normalized spec without the legacy
+// fields, which the baseline replaces.
+func suggestedSpec(spec *entity.RuntimeSpec) *entity.RuntimeSpec {
+	suggested := spec.Clone()
+	suggested.FlavorName = ""
+	suggested.MinInstances = 0
+	suggested.MaxInstances = 0
+	return suggested
+}
+
+// cheaperFlavor returns the most expensive available flavor cheaper than a
+// flavor, or nil.
+func cheaperFlavor(instance *entity.Instance, flavorName string) *entity.Flavor {
+	flavors := FlavorRange(instance.GetAvailableFlavors(), "", "")
+	for i, f := range flavors {
+		if f.Name == flavorName {
+			if i > 0 && flavors[i-1].PricePerHour.Cmp(f.PricePerHour) < 0 {
+				return flavors[i-1]
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// uniqueProfileID returns an ID based on id that no profile of the spec uses.
+func uniqueProfileID(spec *entity.RuntimeSpec, id string) string {
+	used := make(map[string]bool, len(spec.ScalingProfiles))
+	for _, p := range spec.ScalingProfiles {
+		used[p.ID] = true
+	}
+
+	candidate := id
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	return candidate
+}
+
+func ceilDiv(a, b int32) int32 {
+	return (a + b - 1) / b
+}
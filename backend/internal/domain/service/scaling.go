@@ -0,0 +1,157 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// ScalingSimulator simulates the Clever Cloud autoscaling rule: under load, a
+// runtime walks up the flavor range of its profile first, then adds instances.
+type ScalingSimulator struct{}
+
+// NewScalingSimulator creates a new ScalingSimulator.
+func NewScalingSimulator() *ScalingSimulator {
+	return &ScalingSimulator{}
+}
+
+// StateAtLevel returns the configuration of a profile at a load level, given
+// the flavors of the instance. Level 0 is the minimum configuration of the
+// profile and level 5 its maximum. Only available flavors are used.
+//
+// For instance with flavors S to XL and 1 to 4 instances, level 1 runs 1×M,
+// level 3 runs 2×XL and level 5 runs 4×XL.
+func (s *ScalingSimulator) StateAtLevel(profile *entity.ScalingProfile, loadLevel int32, flavors []*entity.Flavor) *entity.ScalingState {
+	available := availableFlavors(flavors)
+
+	minState := func() *entity.ScalingState {
+		price := entity.ZeroMoney(entity.DefaultCurrency)
+		for _, f := range available {
+			if f.Name == profile.MinFlavorName {
+				price = f.PricePerHour
+				break
+			}
+		}
+		return &entity.ScalingState{
+			LoadLevel:  loadLevel,
+			FlavorName: profile.MinFlavorName,
+			Instances:  profile.MinInstances,
+			HourlyCost: price.Times(int64(profile.MinInstances)),
+		}
+	}
+
+	if !profile.Enabled || loadLevel <= entity.MinLoadLevel {
+		return minState()
+	}
+
+	flavorRange := FlavorRange(available, profile.MinFlavorName, profile.MaxFlavorName)
+	if len(flavorRange) == 0 {
+		return minState()
+	}
+
+	maxVerticalSteps := len(flavorRange) - 1
+	maxHorizontalSteps := max(int(profile.MaxInstances-profile.MinInstances), 0)
+	totalSteps := maxVerticalSteps + maxHorizontalSteps
+	if totalSteps == 0 {
+		return &entity.ScalingState{
+			LoadLevel:  loadLevel,
+			FlavorName: flavorRange[0].Name,
+			Instances:  profile.MinInstances,
+			HourlyCost: flavorRange[0].PricePerHour.Times(int64(profile.MinInstances)),
+		}
+	}
+
+	progressRatio := float64(min(loadLevel, entity.MaxLoadLevel)) / entity.MaxLoadLevel
+	stepsToApply := int(math.Round(progressRatio * float64(totalSteps)))
+
+	// Vertical steps first, then horizontal
+	verticalSteps := min(stepsToApply, maxVerticalSteps)
+	horizontalSteps := min(stepsToApply-verticalSteps, maxHorizontalSteps)
+
+	flavor := flavorRange[verticalSteps]
+	instances := profile.MinInstances + int32(horizontalSteps)
+
+	return &entity.ScalingState{
+		LoadLevel:   loadLevel,
+		FlavorName:  flavor.Name,
+		FlavorIndex: verticalSteps,
+		Instances:   instances,
+		HourlyCost:  flavor.PricePerHour.Times(int64(instances)),
+	}
+}
+
+// States returns the configuration of a profile at every load level, from 0 to 5.
+func (s *ScalingSimulator) States(profile *entity.ScalingProfile, flavors []*entity.Flavor) []*entity.ScalingState {
+	states := make([]*entity.ScalingState, 0, entity.MaxLoadLevel-entity.MinLoadLevel+1)
+	for level := int32(entity.MinLoadLevel); level <= entity.MaxLoadLevel; level++ {
+		states = append(states, s.StateAtLevel(profile, level, flavors))
+	}
+	return states
+}
+
+// MaxHourlyCost returns the hourly cost of a profile at full scale. Profile
+// flavor bounds default to the base flavor, and a disabled profile runs its
+// minimum instances of the base flavor.
+func (s *ScalingSimulator) MaxHourlyCost(profile *entity.ScalingProfile, flavors []*entity.Flavor, baseFlavorName string) entity.Money {
+	available := availableFlavors(flavors)
+
+	if !profile.Enabled {
+		for _, f := range available {
+			if f.Name == baseFlavorName {
+				return f.PricePerHour.Times(int64(profile.MinInstances))
+			}
+		}
+		return entity.ZeroMoney(entity.DefaultCurrency)
+	}
+
+	minFlavorName := profile.MinFlavorName
+	if minFlavorName == "" {
+		minFlavorName = baseFlavorName
+	}
+	maxFlavorName := profile.MaxFlavorName
+	if maxFlavorName == "" {
+		maxFlavorName = baseFlavorName
+	}
+
+	flavorRange := FlavorRange(available, minFlavorName, maxFlavorName)
+	if len(flavorRange) == 0 {
+		return entity.ZeroMoney(entity.DefaultCurrency)
+	}
+
+	return flavorRange[len(flavorRange)-1].PricePerHour.Times(int64(profile.MaxInstances))
+}
+
+// FlavorRange returns the available flavors between two flavors (inclusive),
+// sorted by price. All available flavors are returned when either is unknown.
+func FlavorRange(flavors []*entity.Flavor, minFlavorName, maxFlavorName string) []*entity.Flavor {
+	sorted := availableFlavors(flavors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PricePerHour.Cmp(sorted[j].PricePerHour) < 0
+	})
+
+	minIndex, maxIndex := -1, -1
+	for i, f := range sorted {
+		if minIndex == -1 && f.Name == minFlavorName {
+			minIndex = i
+		}
+		if maxIndex == -1 && f.Name == maxFlavorName {
+			maxIndex = i
+		}
+	}
+	if minIndex == -1 || maxIndex == -1 {
+		return sorted
+	}
+
+	return sorted[min(minIndex, maxIndex) : max(minIndex, maxIndex)+1]
+}
+
+func availableFlavors(flavors []*entity.Flavor) []*entity.Flavor {
+	available := make([]*entity.Flavor, 0, len(flavors))
+	for _, f := range flavors {
+		if f.Available {
+			available = append(available, f)
+		}
+	}
+	return available
+}
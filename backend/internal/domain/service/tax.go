@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/domain/entity"
+)
+
+// Invoice mentions of the tax treatments.
+const (
+	reverseChargeMention = "Reverse charge: VAT to be accounted for by the recipient, Article 196 of Directive 2006/112/EC"
+	outOfScopeMention    = "VAT not applicable, Article 259-1 of the French General Tax Code"
+)
+
+// TaxCalculator decides how VAT applies to the invoices of an organization,
+// following the EU rules for electronically supplied services:
+//
+//   - exempt customers pay no VAT;
+//   - customers in the seller country pay its VAT, business or not;
+//   - EU businesses with a VAT number self-assess it (reverse charge);
+//   - EU consumers pay the VAT of their own country;
+//   - customers outside the EU are out of scope of EU VAT.
+type TaxCalculator struct{}
+
+// NewTaxCalculator creates a new TaxCalculator.
+func NewTaxCalculator() *TaxCalculator {
+	return &TaxCalculator{}
+}
+
+// Assess returns the tax assessment of a profile, given the VAT rate of its
+// country, nil outside the EU, and the VAT rate of the seller country.
+func (c *TaxCalculator) Assess(profile *entity.TaxProfile, countryRate, sellerRate *entity.VATRate) *entity.TaxAssessment {
+	assessment := &entity.TaxAssessment{
+		Country:   profile.Country,
+		VATNumber: profile.VATNumber,
+	}
+
+	switch {
+	case profile.Exempt:
+		assessment.Treatment = entity.TaxTreatmentExempt
+		assessment.Mention = fmt.Sprintf("VAT exempt: %s", profile.ExemptionReason)
+	case profile.Country == entity.SellerCountry:
+		assessment.Treatment = entity.TaxTreatmentStandard
+		assessment.Rate = sellerRate.Rate
+	case countryRate == nil:
+		assessment.Treatment = entity.TaxTreatmentOutOfScope
+		assessment.Mention = outOfScopeMention
+	case profile.IsBusiness():
+		assessment.Treatment = entity.TaxTreatmentReverseCharge
+		assessment.Mention = reverseChargeMention
+	default:
+		assessment.Treatment = entity.TaxTreatmentStandard
+		assessment.Rate = countryRate.Rate
+	}
+
+	return assessment
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/c18t-com/clever-pricing-calculator/backend/internal/application/command"
+)
+
+// CatalogRecorder periodically records the catalog in the history.
+type CatalogRecorder struct {
+	handler  *command.RecordCatalogSnapshotHandler
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCatalogRecorder creates a new CatalogRecorder.
+func NewCatalogRecorder(handler *command.RecordCatalogSnapshotHandler, interval time.Duration) *CatalogRecorder {
+	return &CatalogRecorder{
+		handler:  handler,
+		interval: interval,
+	}
+}
+
+// Start records the catalog right away, then at every interval until Shutdown.
+func (r *CatalogRecorder) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.record(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown stops the recorder and waits for a recording in progress.
+func (r *CatalogRecorder) Shutdown() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *CatalogRecorder) record(ctx context.Context) {
+	result, err := r.handler.Handle(ctx, &command.RecordCatalogSnapshotCommand{})
+	if err != nil {
+		log.Printf("Failed to record catalog snapshot: %v", err)
+		return
+	}
+
+	if result.Recorded {
+		log.Printf("Catalog changed, recorded snapshot %s", result.SnapshotID)
+	}
+}